@@ -8,4 +8,6 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/lib/pq v1.10.9
 	golang.org/x/crypto v0.48.0
+	golang.org/x/net v0.49.0
+	gopkg.in/yaml.v3 v3.0.1
 )
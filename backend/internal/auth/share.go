@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MakeShareToken signs a share link ID so it can be handed out as a bearer
+// token. Revocation and expiry are checked against the stored link.
+func MakeShareToken(linkID uuid.UUID, secret string) string {
+	return base64.RawURLEncoding.EncodeToString(linkID[:]) + "." +
+		base64.RawURLEncoding.EncodeToString(signShareLink(linkID, secret))
+}
+
+// ValidateShareToken verifies a share token's signature and returns the link ID
+func ValidateShareToken(token, secret string) (uuid.UUID, error) {
+	id, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, fmt.Errorf("malformed share token")
+	}
+
+	rawID, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("malformed share token: %w", err)
+	}
+	linkID, err := uuid.FromBytes(rawID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("malformed share token: %w", err)
+	}
+
+	rawSig, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("malformed share token: %w", err)
+	}
+	if !hmac.Equal(rawSig, signShareLink(linkID, secret)) {
+		return uuid.Nil, fmt.Errorf("invalid share token signature")
+	}
+
+	return linkID, nil
+}
+
+func signShareLink(linkID uuid.UUID, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("share-link:"))
+	mac.Write(linkID[:])
+	return mac.Sum(nil)
+}
@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ViewerHash pseudonymizes a viewer for analytics deduplication. The hash is
+// keyed with the server secret and salted with the day, so the same viewer
+// hashes differently each day and the identity (an IP address, for anonymous
+// viewers) cannot be recovered from stored hashes.
+func ViewerHash(secret, day, identity string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("viewer:" + day + ":"))
+	mac.Write([]byte(identity))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -1,18 +1,35 @@
 package config
 
-import "sync/atomic"
+import (
+	"database/sql"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCommentMaxDepth is how deeply replies can nest when
+// COMMENT_MAX_DEPTH is not set
+const DefaultCommentMaxDepth = 3
 
 // ApiConfig holds the application configuration
 type ApiConfig struct {
 	FileserverHits atomic.Int32
 	Platform       string
 	JWTSecret      string
+	// DB is the connection pool, for handlers that run several statements in
+	// one transaction
+	DB *sql.DB
+	// CommentMaxDepth is how many levels of replies a top-level comment can have
+	CommentMaxDepth int
+	// CommentEditWindow is how long after posting a comment its author can
+	// edit it; zero means there is no limit
+	CommentEditWindow time.Duration
 }
 
 // NewApiConfig creates a new API configuration
 func NewApiConfig(platform, jwtSecret string) *ApiConfig {
 	return &ApiConfig{
-		Platform:  platform,
-		JWTSecret: jwtSecret,
+		Platform:        platform,
+		JWTSecret:       jwtSecret,
+		CommentMaxDepth: DefaultCommentMaxDepth,
 	}
 }
@@ -14,18 +14,24 @@ import (
 )
 
 const createArticle = `-- name: CreateArticle :one
-INSERT INTO articles (user_id, title, body, summary, thumbnail_url, status)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, user_id, title, body, summary, thumbnail_url, status, published_at, created_at, updated_at, search_vector
+INSERT INTO articles (user_id, title, body, summary, thumbnail_url, status, visibility, slug,
+    canonical_url, original_published_at, language)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, user_id, title, body, summary, thumbnail_url, status, published_at, created_at, updated_at, publication_id, revision, reading_time, clap_count, clapper_count, comment_count, visibility, slug, import_key, canonical_url, original_published_at, language, search_vector, translation_group_id
 `
 
 type CreateArticleParams struct {
-	UserID       uuid.UUID
-	Title        string
-	Body         string
-	Summary      string
-	ThumbnailUrl string
-	Status       string
+	UserID              uuid.UUID
+	Title               string
+	Body                string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	Visibility          string
+	Slug                sql.NullString
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
 }
 
 func (q *Queries) CreateArticle(ctx context.Context, arg CreateArticleParams) (Article, error) {
@@ -36,6 +42,11 @@ func (q *Queries) CreateArticle(ctx context.Context, arg CreateArticleParams) (A
 		arg.Summary,
 		arg.ThumbnailUrl,
 		arg.Status,
+		arg.Visibility,
+		arg.Slug,
+		arg.CanonicalUrl,
+		arg.OriginalPublishedAt,
+		arg.Language,
 	)
 	var i Article
 	err := row.Scan(
@@ -49,53 +60,86 @@ func (q *Queries) CreateArticle(ctx context.Context, arg CreateArticleParams) (A
 		&i.PublishedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PublicationID,
+		&i.Revision,
+		&i.ReadingTime,
+		&i.ClapCount,
+		&i.ClapperCount,
+		&i.CommentCount,
+		&i.Visibility,
+		&i.Slug,
+		&i.ImportKey,
+		&i.CanonicalUrl,
+		&i.OriginalPublishedAt,
+		&i.Language,
 		&i.SearchVector,
+		&i.TranslationGroupID,
 	)
 	return i, err
 }
 
-const deleteArticle = `-- name: DeleteArticle :exec
+const deleteArticle = `-- name: DeleteArticle :execrows
 DELETE FROM articles
-WHERE id = $1 AND user_id = $2
+WHERE id = $1
+    AND ($2::int = 0 OR revision = $2::int)
 `
 
 type DeleteArticleParams struct {
-	ID     uuid.UUID
-	UserID uuid.UUID
+	ID               uuid.UUID
+	ExpectedRevision int32
 }
 
-func (q *Queries) DeleteArticle(ctx context.Context, arg DeleteArticleParams) error {
-	_, err := q.db.ExecContext(ctx, deleteArticle, arg.ID, arg.UserID)
-	return err
+func (q *Queries) DeleteArticle(ctx context.Context, arg DeleteArticleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteArticle, arg.ID, arg.ExpectedRevision)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const getArticleByID = `-- name: GetArticleByID :one
-SELECT a.id, a.user_id, a.title, a.body, a.summary, a.thumbnail_url, a.status, a.published_at, a.created_at, a.updated_at, a.search_vector,
+SELECT a.id, a.user_id, a.title, a.body, a.summary, a.thumbnail_url, a.status, a.published_at, a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.clap_count, a.clapper_count, a.comment_count, a.visibility, a.slug, a.import_key, a.canonical_url, a.original_published_at, a.language, a.search_vector, a.translation_group_id,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    p.name AS publication_name,
+    p.slug AS publication_slug
 FROM articles a
 JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
 WHERE a.id = $1
 `
 
 type GetArticleByIDRow struct {
-	ID              uuid.UUID
-	UserID          uuid.UUID
-	Title           string
-	Body            string
-	Summary         string
-	ThumbnailUrl    string
-	Status          string
-	PublishedAt     sql.NullTime
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	SearchVector    interface{}
-	AuthorUsername  sql.NullString
-	AuthorName      string
-	AuthorAvatarUrl string
-	TotalClaps      int32
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Body                string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	ClapCount           int32
+	ClapperCount        int32
+	CommentCount        int32
+	Visibility          string
+	Slug                sql.NullString
+	ImportKey           sql.NullString
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	SearchVector        interface{}
+	TranslationGroupID  uuid.NullUUID
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
 }
 
 func (q *Queries) GetArticleByID(ctx context.Context, id uuid.UUID) (GetArticleByIDRow, error) {
@@ -112,55 +156,154 @@ func (q *Queries) GetArticleByID(ctx context.Context, id uuid.UUID) (GetArticleB
 		&i.PublishedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PublicationID,
+		&i.Revision,
+		&i.ReadingTime,
+		&i.ClapCount,
+		&i.ClapperCount,
+		&i.CommentCount,
+		&i.Visibility,
+		&i.Slug,
+		&i.ImportKey,
+		&i.CanonicalUrl,
+		&i.OriginalPublishedAt,
+		&i.Language,
 		&i.SearchVector,
+		&i.TranslationGroupID,
 		&i.AuthorUsername,
 		&i.AuthorName,
 		&i.AuthorAvatarUrl,
-		&i.TotalClaps,
+		&i.PublicationName,
+		&i.PublicationSlug,
+	)
+	return i, err
+}
+
+const getArticleBySlug = `-- name: GetArticleBySlug :one
+SELECT id, user_id, title, body, summary, thumbnail_url, status, published_at, created_at, updated_at, publication_id, revision, reading_time, clap_count, clapper_count, comment_count, visibility, slug, import_key, canonical_url, original_published_at, language, search_vector, translation_group_id FROM articles
+WHERE user_id = $1 AND slug = $2
+`
+
+type GetArticleBySlugParams struct {
+	UserID uuid.UUID
+	Slug   sql.NullString
+}
+
+func (q *Queries) GetArticleBySlug(ctx context.Context, arg GetArticleBySlugParams) (Article, error) {
+	row := q.db.QueryRowContext(ctx, getArticleBySlug, arg.UserID, arg.Slug)
+	var i Article
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Body,
+		&i.Summary,
+		&i.ThumbnailUrl,
+		&i.Status,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublicationID,
+		&i.Revision,
+		&i.ReadingTime,
+		&i.ClapCount,
+		&i.ClapperCount,
+		&i.CommentCount,
+		&i.Visibility,
+		&i.Slug,
+		&i.ImportKey,
+		&i.CanonicalUrl,
+		&i.OriginalPublishedAt,
+		&i.Language,
+		&i.SearchVector,
+		&i.TranslationGroupID,
 	)
 	return i, err
 }
 
 const getFeedArticles = `-- name: GetFeedArticles :many
-SELECT a.id, a.user_id, a.title, a.body, a.summary, a.thumbnail_url, a.status, a.published_at, a.created_at, a.updated_at, a.search_vector,
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN $1::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug,
+    (r.finished_at IS NOT NULL)::bool AS finished
 FROM articles a
 JOIN users u ON a.user_id = u.id
-JOIN follows f ON f.following_id = a.user_id
-WHERE f.follower_id = $1 AND a.status = 'published'
-ORDER BY a.published_at DESC
-LIMIT $2 OFFSET $3
+LEFT JOIN publications p ON a.publication_id = p.id
+LEFT JOIN article_reads r ON r.article_id = a.id AND r.user_id = $2
+WHERE a.status = 'published' AND (
+    a.user_id IN (SELECT f.following_id FROM follows f WHERE f.follower_id = $2)
+    OR a.publication_id IN (SELECT pf.publication_id FROM publication_follows pf WHERE pf.follower_id = $2)
+)
+    AND NOT article_muted($2::uuid, a.id, a.user_id, a.title, a.summary)
+    AND ($3::text <> 'hide' OR r.finished_at IS NULL)
+    AND ($4::timestamp IS NULL
+        OR ($3::text = 'last' AND (r.finished_at IS NOT NULL) AND NOT $5::bool)
+        OR (($3::text <> 'last' OR (r.finished_at IS NOT NULL) = $5::bool)
+            AND (a.published_at, a.id) < ($4::timestamp, $6::uuid)))
+ORDER BY (CASE WHEN $3::text = 'last' AND r.finished_at IS NOT NULL THEN 1 ELSE 0 END),
+    a.published_at DESC, a.id DESC
+LIMIT $8 OFFSET $7
 `
 
 type GetFeedArticlesParams struct {
-	FollowerID uuid.UUID
-	Limit      int32
-	Offset     int32
+	IncludeBody    bool
+	FollowerID     uuid.UUID
+	Finished       string
+	CursorTime     sql.NullTime
+	CursorFinished bool
+	CursorID       uuid.NullUUID
+	Offset         int32
+	Limit          int32
 }
 
 type GetFeedArticlesRow struct {
-	ID              uuid.UUID
-	UserID          uuid.UUID
-	Title           string
-	Body            string
-	Summary         string
-	ThumbnailUrl    string
-	Status          string
-	PublishedAt     sql.NullTime
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	SearchVector    interface{}
-	AuthorUsername  sql.NullString
-	AuthorName      string
-	AuthorAvatarUrl string
-	TotalClaps      int32
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	CommentCount        int32
+	Visibility          string
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	Body                string
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	TotalClaps          int32
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
+	Finished            bool
 }
 
+// Articles the follower has finished are listed as usual, last or not at all
+// when finished is show, last or hide.
 func (q *Queries) GetFeedArticles(ctx context.Context, arg GetFeedArticlesParams) ([]GetFeedArticlesRow, error) {
-	rows, err := q.db.QueryContext(ctx, getFeedArticles, arg.FollowerID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getFeedArticles,
+		arg.IncludeBody,
+		arg.FollowerID,
+		arg.Finished,
+		arg.CursorTime,
+		arg.CursorFinished,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -172,18 +315,28 @@ func (q *Queries) GetFeedArticles(ctx context.Context, arg GetFeedArticlesParams
 			&i.ID,
 			&i.UserID,
 			&i.Title,
-			&i.Body,
 			&i.Summary,
 			&i.ThumbnailUrl,
 			&i.Status,
 			&i.PublishedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			&i.SearchVector,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.Body,
 			&i.AuthorUsername,
 			&i.AuthorName,
 			&i.AuthorAvatarUrl,
 			&i.TotalClaps,
+			&i.PublicationName,
+			&i.PublicationSlug,
+			&i.Finished,
 		); err != nil {
 			return nil, err
 		}
@@ -199,44 +352,78 @@ func (q *Queries) GetFeedArticles(ctx context.Context, arg GetFeedArticlesParams
 }
 
 const listArticlesByAuthor = `-- name: ListArticlesByAuthor :many
-SELECT a.id, a.user_id, a.title, a.body, a.summary, a.thumbnail_url, a.status, a.published_at, a.created_at, a.updated_at, a.search_vector,
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN $1::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
 FROM articles a
 JOIN users u ON a.user_id = u.id
-WHERE u.username = $1 AND a.status = 'published'
-ORDER BY a.published_at DESC
-LIMIT $2 OFFSET $3
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE a.status = 'published' AND (
+    u.username = $2 OR EXISTS (
+        SELECT 1 FROM article_collaborators ac
+        JOIN users cu ON ac.user_id = cu.id
+        WHERE ac.article_id = a.id AND cu.username = $2
+            AND ac.role = 'co-author' AND ac.accepted_at IS NOT NULL
+    )
+)
+    AND ($3::timestamp IS NULL
+        OR (a.published_at, a.id) < ($3::timestamp, $4::uuid))
+ORDER BY a.published_at DESC, a.id DESC
+LIMIT $6 OFFSET $5
 `
 
 type ListArticlesByAuthorParams struct {
-	Username sql.NullString
-	Limit    int32
-	Offset   int32
+	IncludeBody bool
+	Username    sql.NullString
+	CursorTime  sql.NullTime
+	CursorID    uuid.NullUUID
+	Offset      int32
+	Limit       int32
 }
 
 type ListArticlesByAuthorRow struct {
-	ID              uuid.UUID
-	UserID          uuid.UUID
-	Title           string
-	Body            string
-	Summary         string
-	ThumbnailUrl    string
-	Status          string
-	PublishedAt     sql.NullTime
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	SearchVector    interface{}
-	AuthorUsername  sql.NullString
-	AuthorName      string
-	AuthorAvatarUrl string
-	TotalClaps      int32
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	CommentCount        int32
+	Visibility          string
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	Body                string
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	TotalClaps          int32
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
 }
 
 func (q *Queries) ListArticlesByAuthor(ctx context.Context, arg ListArticlesByAuthorParams) ([]ListArticlesByAuthorRow, error) {
-	rows, err := q.db.QueryContext(ctx, listArticlesByAuthor, arg.Username, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listArticlesByAuthor,
+		arg.IncludeBody,
+		arg.Username,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -248,18 +435,81 @@ func (q *Queries) ListArticlesByAuthor(ctx context.Context, arg ListArticlesByAu
 			&i.ID,
 			&i.UserID,
 			&i.Title,
-			&i.Body,
 			&i.Summary,
 			&i.ThumbnailUrl,
 			&i.Status,
 			&i.PublishedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			&i.SearchVector,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.Body,
 			&i.AuthorUsername,
 			&i.AuthorName,
 			&i.AuthorAvatarUrl,
 			&i.TotalClaps,
+			&i.PublicationName,
+			&i.PublicationSlug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArticlesForExport = `-- name: ListArticlesForExport :many
+SELECT id, user_id, title, body, summary, thumbnail_url, status, published_at, created_at, updated_at, publication_id, revision, reading_time, clap_count, clapper_count, comment_count, visibility, slug, import_key, canonical_url, original_published_at, language, search_vector, translation_group_id FROM articles
+WHERE user_id = $1
+ORDER BY created_at ASC
+`
+
+func (q *Queries) ListArticlesForExport(ctx context.Context, userID uuid.UUID) ([]Article, error) {
+	rows, err := q.db.QueryContext(ctx, listArticlesForExport, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Article
+	for rows.Next() {
+		var i Article
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Body,
+			&i.Summary,
+			&i.ThumbnailUrl,
+			&i.Status,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.ClapCount,
+			&i.ClapperCount,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.Slug,
+			&i.ImportKey,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.SearchVector,
+			&i.TranslationGroupID,
 		); err != nil {
 			return nil, err
 		}
@@ -275,44 +525,76 @@ func (q *Queries) ListArticlesByAuthor(ctx context.Context, arg ListArticlesByAu
 }
 
 const listDraftsByUser = `-- name: ListDraftsByUser :many
-SELECT a.id, a.user_id, a.title, a.body, a.summary, a.thumbnail_url, a.status, a.published_at, a.created_at, a.updated_at, a.search_vector,
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN $1::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    0::int AS total_claps
+    0::int AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
 FROM articles a
 JOIN users u ON a.user_id = u.id
-WHERE a.user_id = $1 AND a.status = 'draft'
-ORDER BY a.updated_at DESC
-LIMIT $2 OFFSET $3
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE a.status = 'draft' AND (
+    a.user_id = $2 OR EXISTS (
+        SELECT 1 FROM article_collaborators ac
+        WHERE ac.article_id = a.id AND ac.user_id = $2 AND ac.accepted_at IS NOT NULL
+    )
+)
+    AND ($3::timestamp IS NULL
+        OR (a.updated_at, a.id) < ($3::timestamp, $4::uuid))
+ORDER BY a.updated_at DESC, a.id DESC
+LIMIT $6 OFFSET $5
 `
 
 type ListDraftsByUserParams struct {
-	UserID uuid.UUID
-	Limit  int32
-	Offset int32
+	IncludeBody bool
+	UserID      uuid.UUID
+	CursorTime  sql.NullTime
+	CursorID    uuid.NullUUID
+	Offset      int32
+	Limit       int32
 }
 
 type ListDraftsByUserRow struct {
-	ID              uuid.UUID
-	UserID          uuid.UUID
-	Title           string
-	Body            string
-	Summary         string
-	ThumbnailUrl    string
-	Status          string
-	PublishedAt     sql.NullTime
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	SearchVector    interface{}
-	AuthorUsername  sql.NullString
-	AuthorName      string
-	AuthorAvatarUrl string
-	TotalClaps      int32
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	CommentCount        int32
+	Visibility          string
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	Body                string
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	TotalClaps          int32
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
 }
 
 func (q *Queries) ListDraftsByUser(ctx context.Context, arg ListDraftsByUserParams) ([]ListDraftsByUserRow, error) {
-	rows, err := q.db.QueryContext(ctx, listDraftsByUser, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listDraftsByUser,
+		arg.IncludeBody,
+		arg.UserID,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -324,18 +606,27 @@ func (q *Queries) ListDraftsByUser(ctx context.Context, arg ListDraftsByUserPara
 			&i.ID,
 			&i.UserID,
 			&i.Title,
-			&i.Body,
 			&i.Summary,
 			&i.ThumbnailUrl,
 			&i.Status,
 			&i.PublishedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			&i.SearchVector,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.Body,
 			&i.AuthorUsername,
 			&i.AuthorName,
 			&i.AuthorAvatarUrl,
 			&i.TotalClaps,
+			&i.PublicationName,
+			&i.PublicationSlug,
 		); err != nil {
 			return nil, err
 		}
@@ -351,43 +642,72 @@ func (q *Queries) ListDraftsByUser(ctx context.Context, arg ListDraftsByUserPara
 }
 
 const listPublishedArticles = `-- name: ListPublishedArticles :many
-SELECT a.id, a.user_id, a.title, a.body, a.summary, a.thumbnail_url, a.status, a.published_at, a.created_at, a.updated_at, a.search_vector,
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN $1::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
 FROM articles a
 JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
 WHERE a.status = 'published'
-ORDER BY a.published_at DESC
-LIMIT $1 OFFSET $2
+    AND NOT article_muted($2::uuid, a.id, a.user_id, a.title, a.summary)
+    AND ($3::timestamp IS NULL
+        OR (a.published_at, a.id) < ($3::timestamp, $4::uuid))
+ORDER BY a.published_at DESC, a.id DESC
+LIMIT $6 OFFSET $5
 `
 
 type ListPublishedArticlesParams struct {
-	Limit  int32
-	Offset int32
+	IncludeBody bool
+	ViewerID    uuid.NullUUID
+	CursorTime  sql.NullTime
+	CursorID    uuid.NullUUID
+	Offset      int32
+	Limit       int32
 }
 
 type ListPublishedArticlesRow struct {
-	ID              uuid.UUID
-	UserID          uuid.UUID
-	Title           string
-	Body            string
-	Summary         string
-	ThumbnailUrl    string
-	Status          string
-	PublishedAt     sql.NullTime
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	SearchVector    interface{}
-	AuthorUsername  sql.NullString
-	AuthorName      string
-	AuthorAvatarUrl string
-	TotalClaps      int32
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	CommentCount        int32
+	Visibility          string
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	Body                string
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	TotalClaps          int32
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
 }
 
 func (q *Queries) ListPublishedArticles(ctx context.Context, arg ListPublishedArticlesParams) ([]ListPublishedArticlesRow, error) {
-	rows, err := q.db.QueryContext(ctx, listPublishedArticles, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listPublishedArticles,
+		arg.IncludeBody,
+		arg.ViewerID,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -399,18 +719,27 @@ func (q *Queries) ListPublishedArticles(ctx context.Context, arg ListPublishedAr
 			&i.ID,
 			&i.UserID,
 			&i.Title,
-			&i.Body,
 			&i.Summary,
 			&i.ThumbnailUrl,
 			&i.Status,
 			&i.PublishedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			&i.SearchVector,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.Body,
 			&i.AuthorUsername,
 			&i.AuthorName,
 			&i.AuthorAvatarUrl,
 			&i.TotalClaps,
+			&i.PublicationName,
+			&i.PublicationSlug,
 		); err != nil {
 			return nil, err
 		}
@@ -425,20 +754,110 @@ func (q *Queries) ListPublishedArticles(ctx context.Context, arg ListPublishedAr
 	return items, nil
 }
 
+const patchArticle = `-- name: PatchArticle :one
+UPDATE articles
+SET title = COALESCE($1, title),
+    body = COALESCE($2, body),
+    summary = COALESCE($3, summary),
+    thumbnail_url = COALESCE($4, thumbnail_url),
+    visibility = COALESCE($5, visibility),
+    canonical_url = COALESCE($6, canonical_url),
+    original_published_at = CASE WHEN $7::bool
+        THEN $8::timestamp ELSE original_published_at END,
+    language = COALESCE($9, language),
+    revision = revision + 1,
+    updated_at = NOW()
+WHERE id = $10
+    AND ($11::int = 0 OR revision = $11::int)
+RETURNING id, user_id, title, body, summary, thumbnail_url, status, published_at, created_at, updated_at, publication_id, revision, reading_time, clap_count, clapper_count, comment_count, visibility, slug, import_key, canonical_url, original_published_at, language, search_vector, translation_group_id
+`
+
+type PatchArticleParams struct {
+	Title                  sql.NullString
+	Body                   sql.NullString
+	Summary                sql.NullString
+	ThumbnailUrl           sql.NullString
+	Visibility             sql.NullString
+	CanonicalUrl           sql.NullString
+	SetOriginalPublishedAt bool
+	OriginalPublishedAt    sql.NullTime
+	Language               sql.NullString
+	ID                     uuid.UUID
+	ExpectedRevision       int32
+}
+
+// Only non-null arguments are written; the rest keep their current values.
+// original_published_at is written when set_original_published_at is true, so it can be cleared.
+func (q *Queries) PatchArticle(ctx context.Context, arg PatchArticleParams) (Article, error) {
+	row := q.db.QueryRowContext(ctx, patchArticle,
+		arg.Title,
+		arg.Body,
+		arg.Summary,
+		arg.ThumbnailUrl,
+		arg.Visibility,
+		arg.CanonicalUrl,
+		arg.SetOriginalPublishedAt,
+		arg.OriginalPublishedAt,
+		arg.Language,
+		arg.ID,
+		arg.ExpectedRevision,
+	)
+	var i Article
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Body,
+		&i.Summary,
+		&i.ThumbnailUrl,
+		&i.Status,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublicationID,
+		&i.Revision,
+		&i.ReadingTime,
+		&i.ClapCount,
+		&i.ClapperCount,
+		&i.CommentCount,
+		&i.Visibility,
+		&i.Slug,
+		&i.ImportKey,
+		&i.CanonicalUrl,
+		&i.OriginalPublishedAt,
+		&i.Language,
+		&i.SearchVector,
+		&i.TranslationGroupID,
+	)
+	return i, err
+}
+
 const publishArticle = `-- name: PublishArticle :one
 UPDATE articles
-SET status = 'published', published_at = NOW(), updated_at = NOW()
-WHERE id = $1 AND user_id = $2
-RETURNING id, user_id, title, body, summary, thumbnail_url, status, published_at, created_at, updated_at, search_vector
+SET status = 'published', published_at = COALESCE($1::timestamp, NOW()),
+    revision = revision + 1, updated_at = NOW()
+WHERE id = $2
+    AND ($3::int = 0 OR revision = $3::int)
+    AND (NOT $4::bool OR status = 'draft')
+RETURNING id, user_id, title, body, summary, thumbnail_url, status, published_at, created_at, updated_at, publication_id, revision, reading_time, clap_count, clapper_count, comment_count, visibility, slug, import_key, canonical_url, original_published_at, language, search_vector, translation_group_id
 `
 
 type PublishArticleParams struct {
-	ID     uuid.UUID
-	UserID uuid.UUID
+	PublishedAt      sql.NullTime
+	ID               uuid.UUID
+	ExpectedRevision int32
+	DraftOnly        bool
 }
 
+// published_at defaults to now; imports pass the document's own date.
+// draft_only leaves an already published article alone.
 func (q *Queries) PublishArticle(ctx context.Context, arg PublishArticleParams) (Article, error) {
-	row := q.db.QueryRowContext(ctx, publishArticle, arg.ID, arg.UserID)
+	row := q.db.QueryRowContext(ctx, publishArticle,
+		arg.PublishedAt,
+		arg.ID,
+		arg.ExpectedRevision,
+		arg.DraftOnly,
+	)
 	var i Article
 	err := row.Scan(
 		&i.ID,
@@ -451,50 +870,101 @@ func (q *Queries) PublishArticle(ctx context.Context, arg PublishArticleParams)
 		&i.PublishedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PublicationID,
+		&i.Revision,
+		&i.ReadingTime,
+		&i.ClapCount,
+		&i.ClapperCount,
+		&i.CommentCount,
+		&i.Visibility,
+		&i.Slug,
+		&i.ImportKey,
+		&i.CanonicalUrl,
+		&i.OriginalPublishedAt,
+		&i.Language,
 		&i.SearchVector,
+		&i.TranslationGroupID,
 	)
 	return i, err
 }
 
 const searchArticles = `-- name: SearchArticles :many
-SELECT a.id, a.user_id, a.title, a.body, a.summary, a.thumbnail_url, a.status, a.published_at, a.created_at, a.updated_at, a.search_vector,
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN $1::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug,
+    ts_rank(a.search_vector, article_tsquery($2, $3))::real AS rank
 FROM articles a
 JOIN users u ON a.user_id = u.id
-WHERE a.status = 'published' AND a.search_vector @@ plainto_tsquery('english', $1)
-ORDER BY ts_rank(a.search_vector, plainto_tsquery('english', $1)) DESC
-LIMIT $2 OFFSET $3
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE a.status = 'published' AND a.search_vector @@ article_tsquery($2, $3)
+    AND ($2::text IS NULL OR a.language = $2)
+    AND NOT article_muted($4::uuid, a.id, a.user_id, a.title, a.summary)
+    AND ($5::real IS NULL
+        OR (ts_rank(a.search_vector, article_tsquery($2, $3)), a.id)
+            < ($5::real, $6::uuid))
+ORDER BY rank DESC, a.id DESC
+LIMIT $8 OFFSET $7
 `
 
 type SearchArticlesParams struct {
-	PlaintoTsquery string
-	Limit          int32
-	Offset         int32
+	IncludeBody bool
+	Lang        sql.NullString
+	Query       string
+	ViewerID    uuid.NullUUID
+	CursorRank  sql.NullFloat64
+	CursorID    uuid.NullUUID
+	Offset      int32
+	Limit       int32
 }
 
 type SearchArticlesRow struct {
-	ID              uuid.UUID
-	UserID          uuid.UUID
-	Title           string
-	Body            string
-	Summary         string
-	ThumbnailUrl    string
-	Status          string
-	PublishedAt     sql.NullTime
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	SearchVector    interface{}
-	AuthorUsername  sql.NullString
-	AuthorName      string
-	AuthorAvatarUrl string
-	TotalClaps      int32
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	CommentCount        int32
+	Visibility          string
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	Body                string
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	TotalClaps          int32
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
+	Rank                float32
 }
 
+// With a language, only articles in it match, stemmed for it; without one the
+// query is stemmed for every supported language.
 func (q *Queries) SearchArticles(ctx context.Context, arg SearchArticlesParams) ([]SearchArticlesRow, error) {
-	rows, err := q.db.QueryContext(ctx, searchArticles, arg.PlaintoTsquery, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, searchArticles,
+		arg.IncludeBody,
+		arg.Lang,
+		arg.Query,
+		arg.ViewerID,
+		arg.CursorRank,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -506,18 +976,28 @@ func (q *Queries) SearchArticles(ctx context.Context, arg SearchArticlesParams)
 			&i.ID,
 			&i.UserID,
 			&i.Title,
-			&i.Body,
 			&i.Summary,
 			&i.ThumbnailUrl,
 			&i.Status,
 			&i.PublishedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			&i.SearchVector,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.Body,
 			&i.AuthorUsername,
 			&i.AuthorName,
 			&i.AuthorAvatarUrl,
 			&i.TotalClaps,
+			&i.PublicationName,
+			&i.PublicationSlug,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -532,30 +1012,62 @@ func (q *Queries) SearchArticles(ctx context.Context, arg SearchArticlesParams)
 	return items, nil
 }
 
+const setArticleSlug = `-- name: SetArticleSlug :exec
+UPDATE articles
+SET slug = $2
+WHERE id = $1
+`
+
+type SetArticleSlugParams struct {
+	ID   uuid.UUID
+	Slug sql.NullString
+}
+
+func (q *Queries) SetArticleSlug(ctx context.Context, arg SetArticleSlugParams) error {
+	_, err := q.db.ExecContext(ctx, setArticleSlug, arg.ID, arg.Slug)
+	return err
+}
+
 const updateArticle = `-- name: UpdateArticle :one
 UPDATE articles
-SET title = $2, body = $3, summary = $4, thumbnail_url = $5, updated_at = NOW()
-WHERE id = $1 AND user_id = $6
-RETURNING id, user_id, title, body, summary, thumbnail_url, status, published_at, created_at, updated_at, search_vector
+SET title = $1, body = $2, summary = $3,
+    thumbnail_url = $4,
+    visibility = COALESCE($5, visibility),
+    canonical_url = COALESCE($6, canonical_url),
+    original_published_at = COALESCE($7, original_published_at),
+    language = COALESCE($8, language),
+    revision = revision + 1, updated_at = NOW()
+WHERE id = $9
+    AND ($10::int = 0 OR revision = $10::int)
+RETURNING id, user_id, title, body, summary, thumbnail_url, status, published_at, created_at, updated_at, publication_id, revision, reading_time, clap_count, clapper_count, comment_count, visibility, slug, import_key, canonical_url, original_published_at, language, search_vector, translation_group_id
 `
 
 type UpdateArticleParams struct {
-	ID           uuid.UUID
-	Title        string
-	Body         string
-	Summary      string
-	ThumbnailUrl string
-	UserID       uuid.UUID
+	Title               string
+	Body                string
+	Summary             string
+	ThumbnailUrl        string
+	Visibility          sql.NullString
+	CanonicalUrl        sql.NullString
+	OriginalPublishedAt sql.NullTime
+	Language            sql.NullString
+	ID                  uuid.UUID
+	ExpectedRevision    int32
 }
 
+// A zero expected_revision skips the optimistic concurrency check.
 func (q *Queries) UpdateArticle(ctx context.Context, arg UpdateArticleParams) (Article, error) {
 	row := q.db.QueryRowContext(ctx, updateArticle,
-		arg.ID,
 		arg.Title,
 		arg.Body,
 		arg.Summary,
 		arg.ThumbnailUrl,
-		arg.UserID,
+		arg.Visibility,
+		arg.CanonicalUrl,
+		arg.OriginalPublishedAt,
+		arg.Language,
+		arg.ID,
+		arg.ExpectedRevision,
 	)
 	var i Article
 	err := row.Scan(
@@ -569,7 +1081,20 @@ func (q *Queries) UpdateArticle(ctx context.Context, arg UpdateArticleParams) (A
 		&i.PublishedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PublicationID,
+		&i.Revision,
+		&i.ReadingTime,
+		&i.ClapCount,
+		&i.ClapperCount,
+		&i.CommentCount,
+		&i.Visibility,
+		&i.Slug,
+		&i.ImportKey,
+		&i.CanonicalUrl,
+		&i.OriginalPublishedAt,
+		&i.Language,
 		&i.SearchVector,
+		&i.TranslationGroupID,
 	)
 	return i, err
 }
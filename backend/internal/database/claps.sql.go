@@ -7,18 +7,19 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 const getArticleClapCount = `-- name: GetArticleClapCount :one
-SELECT COALESCE(SUM(count), 0)::int AS total_claps
-FROM claps
-WHERE article_id = $1
+SELECT clap_count AS total_claps
+FROM articles
+WHERE id = $1
 `
 
-func (q *Queries) GetArticleClapCount(ctx context.Context, articleID uuid.UUID) (int32, error) {
-	row := q.db.QueryRowContext(ctx, getArticleClapCount, articleID)
+func (q *Queries) GetArticleClapCount(ctx context.Context, id uuid.UUID) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getArticleClapCount, id)
 	var total_claps int32
 	err := row.Scan(&total_claps)
 	return total_claps, err
@@ -43,12 +44,33 @@ func (q *Queries) GetUserClapForArticle(ctx context.Context, arg GetUserClapForA
 }
 
 const upsertClap = `-- name: UpsertClap :one
-INSERT INTO claps (article_id, user_id, count)
-VALUES ($1, $2, $3)
-ON CONFLICT (article_id, user_id) DO UPDATE
-SET count = LEAST(claps.count + EXCLUDED.count, 50),
-    updated_at = NOW()
-RETURNING id, article_id, user_id, count, created_at, updated_at
+WITH previous AS (
+    SELECT c.count FROM claps c
+    WHERE c.article_id = $1 AND c.user_id = $2
+    FOR UPDATE
+), upserted AS (
+    INSERT INTO claps (article_id, user_id, count)
+    VALUES ($1, $2, $3)
+    ON CONFLICT (article_id, user_id) DO UPDATE
+    SET count = LEAST(claps.count + EXCLUDED.count, 50),
+        updated_at = NOW()
+    WHERE EXISTS (SELECT 1 FROM previous)
+    RETURNING claps.id, claps.article_id, claps.user_id, claps.count, claps.created_at, claps.updated_at, (claps.xmax = 0) AS inserted
+), counters AS (
+    UPDATE articles a
+    SET clap_count = a.clap_count + u.count - COALESCE((SELECT p.count FROM previous p), 0),
+        clapper_count = a.clapper_count + (CASE WHEN u.inserted THEN 1 ELSE 0 END)
+    FROM upserted u
+    WHERE a.id = u.article_id
+), daily AS (
+    INSERT INTO article_daily_stats AS s (article_id, day, claps, clappers)
+    SELECT u.article_id, (NOW() AT TIME ZONE 'UTC')::date, u.count - COALESCE((SELECT p.count FROM previous p), 0),
+        (CASE WHEN u.inserted THEN 1 ELSE 0 END)
+    FROM upserted u
+    ON CONFLICT (article_id, day) DO UPDATE
+    SET claps = s.claps + EXCLUDED.claps, clappers = s.clappers + EXCLUDED.clappers
+)
+SELECT u.id, u.article_id, u.user_id, u.count, u.created_at, u.updated_at FROM upserted u
 `
 
 type UpsertClapParams struct {
@@ -57,9 +79,24 @@ type UpsertClapParams struct {
 	Count     int32
 }
 
-func (q *Queries) UpsertClap(ctx context.Context, arg UpsertClapParams) (Clap, error) {
+type UpsertClapRow struct {
+	ID        uuid.UUID
+	ArticleID uuid.UUID
+	UserID    uuid.UUID
+	Count     int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Adds claps and applies the change to the article's clap and clapper
+// counters and today's stats rollup in the same statement. A reader's first
+// clap inserts their row (xmax = 0) and counts them as a clapper. Later claps
+// lock the previous row so concurrent claps see each other's totals; a row
+// that another request inserted after this statement's snapshot is left
+// alone and nothing is returned, so the caller can retry.
+func (q *Queries) UpsertClap(ctx context.Context, arg UpsertClapParams) (UpsertClapRow, error) {
 	row := q.db.QueryRowContext(ctx, upsertClap, arg.ArticleID, arg.UserID, arg.Count)
-	var i Clap
+	var i UpsertClapRow
 	err := row.Scan(
 		&i.ID,
 		&i.ArticleID,
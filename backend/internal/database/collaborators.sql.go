@@ -0,0 +1,335 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: collaborators.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const acceptCollaboration = `-- name: AcceptCollaboration :one
+UPDATE article_collaborators
+SET accepted_at = NOW()
+WHERE article_id = $1 AND user_id = $2 AND accepted_at IS NULL
+RETURNING article_id, user_id, role, invited_by, accepted_at, created_at
+`
+
+type AcceptCollaborationParams struct {
+	ArticleID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (q *Queries) AcceptCollaboration(ctx context.Context, arg AcceptCollaborationParams) (ArticleCollaborator, error) {
+	row := q.db.QueryRowContext(ctx, acceptCollaboration, arg.ArticleID, arg.UserID)
+	var i ArticleCollaborator
+	err := row.Scan(
+		&i.ArticleID,
+		&i.UserID,
+		&i.Role,
+		&i.InvitedBy,
+		&i.AcceptedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getArticleRole = `-- name: GetArticleRole :one
+SELECT (CASE
+    WHEN a.user_id = $2 THEN 'owner'
+    ELSE COALESCE(
+        (
+            SELECT ac.role FROM article_collaborators ac
+            WHERE ac.article_id = a.id AND ac.user_id = $2 AND ac.accepted_at IS NOT NULL
+        ),
+        (
+            SELECT 'editor' FROM publication_members pm
+            WHERE pm.user_id = $2 AND pm.role IN ('owner', 'editor') AND (
+                pm.publication_id = a.publication_id OR EXISTS (
+                    SELECT 1 FROM publication_submissions ps
+                    WHERE ps.article_id = a.id AND ps.publication_id = pm.publication_id
+                        AND ps.status IN ('pending', 'changes_requested', 'accepted')
+                )
+            )
+            LIMIT 1
+        ),
+        ''
+    )
+END)::text AS role
+FROM articles a
+WHERE a.id = $1
+`
+
+type GetArticleRoleParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+// Editors and owners of the article's publication, or of one still reviewing
+// it, act as its editors.
+func (q *Queries) GetArticleRole(ctx context.Context, arg GetArticleRoleParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getArticleRole, arg.ID, arg.UserID)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
+const inviteCollaborator = `-- name: InviteCollaborator :one
+INSERT INTO article_collaborators (article_id, user_id, role, invited_by)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (article_id, user_id) DO UPDATE
+SET role = EXCLUDED.role
+WHERE article_collaborators.accepted_at IS NULL
+RETURNING article_id, user_id, role, invited_by, accepted_at, created_at
+`
+
+type InviteCollaboratorParams struct {
+	ArticleID uuid.UUID
+	UserID    uuid.UUID
+	Role      string
+	InvitedBy uuid.UUID
+}
+
+// Re-inviting a user whose invitation is still pending replaces it; an
+// accepted collaborator is left unchanged and no row is returned.
+func (q *Queries) InviteCollaborator(ctx context.Context, arg InviteCollaboratorParams) (ArticleCollaborator, error) {
+	row := q.db.QueryRowContext(ctx, inviteCollaborator,
+		arg.ArticleID,
+		arg.UserID,
+		arg.Role,
+		arg.InvitedBy,
+	)
+	var i ArticleCollaborator
+	err := row.Scan(
+		&i.ArticleID,
+		&i.UserID,
+		&i.Role,
+		&i.InvitedBy,
+		&i.AcceptedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listArticleCoAuthors = `-- name: ListArticleCoAuthors :many
+SELECT u.id, u.username, u.name, u.avatar_url
+FROM users u
+JOIN article_collaborators ac ON ac.user_id = u.id
+WHERE ac.article_id = $1 AND ac.role = 'co-author' AND ac.accepted_at IS NOT NULL
+ORDER BY ac.accepted_at ASC
+`
+
+type ListArticleCoAuthorsRow struct {
+	ID        uuid.UUID
+	Username  sql.NullString
+	Name      string
+	AvatarUrl string
+}
+
+func (q *Queries) ListArticleCoAuthors(ctx context.Context, articleID uuid.UUID) ([]ListArticleCoAuthorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listArticleCoAuthors, articleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArticleCoAuthorsRow
+	for rows.Next() {
+		var i ListArticleCoAuthorsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Name,
+			&i.AvatarUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArticleCollaborators = `-- name: ListArticleCollaborators :many
+SELECT ac.article_id, ac.user_id, ac.role, ac.invited_by, ac.accepted_at, ac.created_at,
+    u.username,
+    u.name,
+    u.avatar_url
+FROM article_collaborators ac
+JOIN users u ON ac.user_id = u.id
+WHERE ac.article_id = $1
+ORDER BY ac.created_at ASC
+`
+
+type ListArticleCollaboratorsRow struct {
+	ArticleID  uuid.UUID
+	UserID     uuid.UUID
+	Role       string
+	InvitedBy  uuid.UUID
+	AcceptedAt sql.NullTime
+	CreatedAt  time.Time
+	Username   sql.NullString
+	Name       string
+	AvatarUrl  string
+}
+
+func (q *Queries) ListArticleCollaborators(ctx context.Context, articleID uuid.UUID) ([]ListArticleCollaboratorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listArticleCollaborators, articleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArticleCollaboratorsRow
+	for rows.Next() {
+		var i ListArticleCollaboratorsRow
+		if err := rows.Scan(
+			&i.ArticleID,
+			&i.UserID,
+			&i.Role,
+			&i.InvitedBy,
+			&i.AcceptedAt,
+			&i.CreatedAt,
+			&i.Username,
+			&i.Name,
+			&i.AvatarUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCoAuthorsForArticles = `-- name: ListCoAuthorsForArticles :many
+SELECT ac.article_id, u.id, u.username, u.name, u.avatar_url
+FROM article_collaborators ac
+JOIN users u ON ac.user_id = u.id
+WHERE ac.article_id = ANY($1::uuid[])
+    AND ac.role = 'co-author' AND ac.accepted_at IS NOT NULL
+ORDER BY ac.article_id, ac.accepted_at ASC
+`
+
+type ListCoAuthorsForArticlesRow struct {
+	ArticleID uuid.UUID
+	ID        uuid.UUID
+	Username  sql.NullString
+	Name      string
+	AvatarUrl string
+}
+
+func (q *Queries) ListCoAuthorsForArticles(ctx context.Context, articleIds []uuid.UUID) ([]ListCoAuthorsForArticlesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCoAuthorsForArticles, pq.Array(articleIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCoAuthorsForArticlesRow
+	for rows.Next() {
+		var i ListCoAuthorsForArticlesRow
+		if err := rows.Scan(
+			&i.ArticleID,
+			&i.ID,
+			&i.Username,
+			&i.Name,
+			&i.AvatarUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingInvitations = `-- name: ListPendingInvitations :many
+SELECT ac.article_id, ac.user_id, ac.role, ac.invited_by, ac.accepted_at, ac.created_at,
+    a.title AS article_title,
+    u.username AS inviter_username,
+    u.name AS inviter_name
+FROM article_collaborators ac
+JOIN articles a ON ac.article_id = a.id
+JOIN users u ON ac.invited_by = u.id
+WHERE ac.user_id = $1 AND ac.accepted_at IS NULL
+ORDER BY ac.created_at DESC
+`
+
+type ListPendingInvitationsRow struct {
+	ArticleID       uuid.UUID
+	UserID          uuid.UUID
+	Role            string
+	InvitedBy       uuid.UUID
+	AcceptedAt      sql.NullTime
+	CreatedAt       time.Time
+	ArticleTitle    string
+	InviterUsername sql.NullString
+	InviterName     string
+}
+
+func (q *Queries) ListPendingInvitations(ctx context.Context, userID uuid.UUID) ([]ListPendingInvitationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingInvitations, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPendingInvitationsRow
+	for rows.Next() {
+		var i ListPendingInvitationsRow
+		if err := rows.Scan(
+			&i.ArticleID,
+			&i.UserID,
+			&i.Role,
+			&i.InvitedBy,
+			&i.AcceptedAt,
+			&i.CreatedAt,
+			&i.ArticleTitle,
+			&i.InviterUsername,
+			&i.InviterName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeCollaborator = `-- name: RemoveCollaborator :exec
+DELETE FROM article_collaborators
+WHERE article_id = $1 AND user_id = $2
+`
+
+type RemoveCollaboratorParams struct {
+	ArticleID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (q *Queries) RemoveCollaborator(ctx context.Context, arg RemoveCollaboratorParams) error {
+	_, err := q.db.ExecContext(ctx, removeCollaborator, arg.ArticleID, arg.UserID)
+	return err
+}
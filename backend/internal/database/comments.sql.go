@@ -13,33 +13,54 @@ import (
 	"github.com/google/uuid"
 )
 
-const countCommentsByArticle = `-- name: CountCommentsByArticle :one
-SELECT COUNT(*)::int FROM comments
-WHERE article_id = $1
-`
-
-func (q *Queries) CountCommentsByArticle(ctx context.Context, articleID uuid.UUID) (int32, error) {
-	row := q.db.QueryRowContext(ctx, countCommentsByArticle, articleID)
-	var column_1 int32
-	err := row.Scan(&column_1)
-	return column_1, err
-}
-
 const createComment = `-- name: CreateComment :one
-INSERT INTO comments (article_id, user_id, body)
-VALUES ($1, $2, $3)
-RETURNING id, article_id, user_id, body, created_at, updated_at
+WITH inserted AS (
+    INSERT INTO comments (article_id, user_id, body, parent_id, depth)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING id, article_id, user_id, body, created_at, updated_at, parent_id, depth, deleted_at, edited_at
+), counters AS (
+    UPDATE articles SET comment_count = comment_count + 1
+    WHERE id = $1
+), daily AS (
+    INSERT INTO article_daily_stats AS s (article_id, day, comments)
+    VALUES ($1, (NOW() AT TIME ZONE 'UTC')::date, 1)
+    ON CONFLICT (article_id, day) DO UPDATE SET comments = s.comments + 1
+)
+SELECT id, article_id, user_id, body, created_at, updated_at, parent_id, depth, deleted_at, edited_at FROM inserted
 `
 
 type CreateCommentParams struct {
 	ArticleID uuid.UUID
 	UserID    uuid.UUID
 	Body      string
+	ParentID  uuid.NullUUID
+	Depth     int32
 }
 
-func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error) {
-	row := q.db.QueryRowContext(ctx, createComment, arg.ArticleID, arg.UserID, arg.Body)
-	var i Comment
+type CreateCommentRow struct {
+	ID        uuid.UUID
+	ArticleID uuid.UUID
+	UserID    uuid.UUID
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ParentID  uuid.NullUUID
+	Depth     int32
+	DeletedAt sql.NullTime
+	EditedAt  sql.NullTime
+}
+
+// Inserts a comment and bumps the article's comment counter and today's stats
+// rollup in one statement
+func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (CreateCommentRow, error) {
+	row := q.db.QueryRowContext(ctx, createComment,
+		arg.ArticleID,
+		arg.UserID,
+		arg.Body,
+		arg.ParentID,
+		arg.Depth,
+	)
+	var i CreateCommentRow
 	err := row.Scan(
 		&i.ID,
 		&i.ArticleID,
@@ -47,13 +68,31 @@ func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (C
 		&i.Body,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ParentID,
+		&i.Depth,
+		&i.DeletedAt,
+		&i.EditedAt,
 	)
 	return i, err
 }
 
-const deleteComment = `-- name: DeleteComment :exec
-DELETE FROM comments
-WHERE id = $1 AND user_id = $2
+const deleteComment = `-- name: DeleteComment :execrows
+WITH target AS (
+    SELECT c.id, c.article_id,
+        EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = c.id) AS has_replies
+    FROM comments c
+    WHERE c.id = $1 AND c.user_id = $2 AND c.deleted_at IS NULL
+), removed AS (
+    DELETE FROM comments c
+    USING target t
+    WHERE c.id = t.id AND NOT t.has_replies
+), blanked AS (
+    UPDATE comments c SET body = '', deleted_at = NOW(), updated_at = NOW()
+    FROM target t
+    WHERE c.id = t.id AND t.has_replies
+)
+UPDATE articles a SET comment_count = a.comment_count - 1
+WHERE a.id IN (SELECT t.article_id FROM target t)
 `
 
 type DeleteCommentParams struct {
@@ -61,16 +100,23 @@ type DeleteCommentParams struct {
 	UserID uuid.UUID
 }
 
-func (q *Queries) DeleteComment(ctx context.Context, arg DeleteCommentParams) error {
-	_, err := q.db.ExecContext(ctx, deleteComment, arg.ID, arg.UserID)
-	return err
+// Deletes a comment and decrements the article's comment counter in one
+// statement. A comment with replies is blanked into a placeholder instead.
+func (q *Queries) DeleteComment(ctx context.Context, arg DeleteCommentParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteComment, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const getCommentByID = `-- name: GetCommentByID :one
-SELECT c.id, c.article_id, c.user_id, c.body, c.created_at, c.updated_at,
+SELECT c.id, c.article_id, c.user_id, c.body, c.created_at, c.updated_at, c.parent_id, c.depth, c.deleted_at, c.edited_at,
     u.username AS author_username,
     u.name AS author_name,
-    u.avatar_url AS author_avatar_url
+    u.avatar_url AS author_avatar_url,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id
+        AND (r.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments rr WHERE rr.parent_id = r.id)))::int AS reply_count
 FROM comments c
 JOIN users u ON c.user_id = u.id
 WHERE c.id = $1
@@ -83,9 +129,14 @@ type GetCommentByIDRow struct {
 	Body            string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	ParentID        uuid.NullUUID
+	Depth           int32
+	DeletedAt       sql.NullTime
+	EditedAt        sql.NullTime
 	AuthorUsername  sql.NullString
 	AuthorName      string
 	AuthorAvatarUrl string
+	ReplyCount      int32
 }
 
 func (q *Queries) GetCommentByID(ctx context.Context, id uuid.UUID) (GetCommentByIDRow, error) {
@@ -98,29 +149,176 @@ func (q *Queries) GetCommentByID(ctx context.Context, id uuid.UUID) (GetCommentB
 		&i.Body,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ParentID,
+		&i.Depth,
+		&i.DeletedAt,
+		&i.EditedAt,
 		&i.AuthorUsername,
 		&i.AuthorName,
 		&i.AuthorAvatarUrl,
+		&i.ReplyCount,
 	)
 	return i, err
 }
 
+const listCommentEdits = `-- name: ListCommentEdits :many
+SELECT id, comment_id, body, replaced_at FROM comment_edits
+WHERE comment_id = $1
+ORDER BY replaced_at ASC, id ASC
+`
+
+// Earlier versions of a comment, oldest first
+func (q *Queries) ListCommentEdits(ctx context.Context, commentID uuid.UUID) ([]CommentEdit, error) {
+	rows, err := q.db.QueryContext(ctx, listCommentEdits, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CommentEdit
+	for rows.Next() {
+		var i CommentEdit
+		if err := rows.Scan(
+			&i.ID,
+			&i.CommentID,
+			&i.Body,
+			&i.ReplacedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCommentReplies = `-- name: ListCommentReplies :many
+SELECT c.id, c.article_id, c.user_id, c.body, c.created_at, c.updated_at, c.parent_id, c.depth, c.deleted_at, c.edited_at,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id
+        AND (r.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments rr WHERE rr.parent_id = r.id)))::int AS reply_count,
+    EXISTS (
+        SELECT 1 FROM muted_authors ma
+        WHERE ma.user_id = $1::uuid AND ma.muted_user_id = c.user_id
+    )::bool AS collapsed
+FROM comments c
+JOIN users u ON c.user_id = u.id
+WHERE c.parent_id = $2
+    AND (c.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = c.id))
+    AND ($3::timestamp IS NULL
+        OR (c.created_at, c.id) > ($3::timestamp, $4::uuid))
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT $6 OFFSET $5
+`
+
+type ListCommentRepliesParams struct {
+	ViewerID   uuid.NullUUID
+	ParentID   uuid.NullUUID
+	CursorTime sql.NullTime
+	CursorID   uuid.NullUUID
+	Offset     int32
+	Limit      int32
+}
+
+type ListCommentRepliesRow struct {
+	ID              uuid.UUID
+	ArticleID       uuid.UUID
+	UserID          uuid.UUID
+	Body            string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	ParentID        uuid.NullUUID
+	Depth           int32
+	DeletedAt       sql.NullTime
+	EditedAt        sql.NullTime
+	AuthorUsername  sql.NullString
+	AuthorName      string
+	AuthorAvatarUrl string
+	ReplyCount      int32
+	Collapsed       bool
+}
+
+// Direct replies to a comment, oldest first, flagged and pruned as in ListCommentsByArticle
+func (q *Queries) ListCommentReplies(ctx context.Context, arg ListCommentRepliesParams) ([]ListCommentRepliesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCommentReplies,
+		arg.ViewerID,
+		arg.ParentID,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCommentRepliesRow
+	for rows.Next() {
+		var i ListCommentRepliesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ArticleID,
+			&i.UserID,
+			&i.Body,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ParentID,
+			&i.Depth,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.AuthorUsername,
+			&i.AuthorName,
+			&i.AuthorAvatarUrl,
+			&i.ReplyCount,
+			&i.Collapsed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCommentsByArticle = `-- name: ListCommentsByArticle :many
-SELECT c.id, c.article_id, c.user_id, c.body, c.created_at, c.updated_at,
+SELECT c.id, c.article_id, c.user_id, c.body, c.created_at, c.updated_at, c.parent_id, c.depth, c.deleted_at, c.edited_at,
     u.username AS author_username,
     u.name AS author_name,
-    u.avatar_url AS author_avatar_url
+    u.avatar_url AS author_avatar_url,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id
+        AND (r.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments rr WHERE rr.parent_id = r.id)))::int AS reply_count,
+    EXISTS (
+        SELECT 1 FROM muted_authors ma
+        WHERE ma.user_id = $1::uuid AND ma.muted_user_id = c.user_id
+    )::bool AS collapsed
 FROM comments c
 JOIN users u ON c.user_id = u.id
-WHERE c.article_id = $1
-ORDER BY c.created_at ASC
-LIMIT $2 OFFSET $3
+WHERE c.article_id = $2 AND c.parent_id IS NULL
+    AND (c.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = c.id))
+    AND ($3::timestamp IS NULL
+        OR (c.created_at, c.id) > ($3::timestamp, $4::uuid))
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT $6 OFFSET $5
 `
 
 type ListCommentsByArticleParams struct {
-	ArticleID uuid.UUID
-	Limit     int32
-	Offset    int32
+	ViewerID   uuid.NullUUID
+	ArticleID  uuid.UUID
+	CursorTime sql.NullTime
+	CursorID   uuid.NullUUID
+	Offset     int32
+	Limit      int32
 }
 
 type ListCommentsByArticleRow struct {
@@ -130,13 +328,29 @@ type ListCommentsByArticleRow struct {
 	Body            string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	ParentID        uuid.NullUUID
+	Depth           int32
+	DeletedAt       sql.NullTime
+	EditedAt        sql.NullTime
 	AuthorUsername  sql.NullString
 	AuthorName      string
 	AuthorAvatarUrl string
+	ReplyCount      int32
+	Collapsed       bool
 }
 
+// Top-level comments with their reply counts. Comments by authors the viewer
+// has muted are flagged so clients collapse them, and deleted comments are
+// kept as placeholders only while they have replies.
 func (q *Queries) ListCommentsByArticle(ctx context.Context, arg ListCommentsByArticleParams) ([]ListCommentsByArticleRow, error) {
-	rows, err := q.db.QueryContext(ctx, listCommentsByArticle, arg.ArticleID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listCommentsByArticle,
+		arg.ViewerID,
+		arg.ArticleID,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -151,9 +365,15 @@ func (q *Queries) ListCommentsByArticle(ctx context.Context, arg ListCommentsByA
 			&i.Body,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ParentID,
+			&i.Depth,
+			&i.DeletedAt,
+			&i.EditedAt,
 			&i.AuthorUsername,
 			&i.AuthorName,
 			&i.AuthorAvatarUrl,
+			&i.ReplyCount,
+			&i.Collapsed,
 		); err != nil {
 			return nil, err
 		}
@@ -167,3 +387,53 @@ func (q *Queries) ListCommentsByArticle(ctx context.Context, arg ListCommentsByA
 	}
 	return items, nil
 }
+
+const updateComment = `-- name: UpdateComment :one
+WITH previous AS (
+    SELECT c.id, c.body FROM comments c
+    WHERE c.id = $2 AND c.user_id = $3 AND c.deleted_at IS NULL
+      AND ($4::float8 IS NULL
+           OR c.created_at > NOW() - make_interval(secs => $4::float8))
+    FOR UPDATE
+), archived AS (
+    INSERT INTO comment_edits (comment_id, body)
+    SELECT p.id, p.body FROM previous p
+)
+UPDATE comments c
+SET body = $1, edited_at = NOW(), updated_at = NOW()
+FROM previous p
+WHERE c.id = p.id
+RETURNING c.id, c.article_id, c.user_id, c.body, c.created_at, c.updated_at, c.parent_id, c.depth, c.deleted_at, c.edited_at
+`
+
+type UpdateCommentParams struct {
+	Body              string
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	EditWindowSeconds sql.NullFloat64
+}
+
+// Replaces the body of an author's comment, keeping the previous version.
+// A NULL edit window means comments can be edited at any time.
+func (q *Queries) UpdateComment(ctx context.Context, arg UpdateCommentParams) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, updateComment,
+		arg.Body,
+		arg.ID,
+		arg.UserID,
+		arg.EditWindowSeconds,
+	)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.ArticleID,
+		&i.UserID,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ParentID,
+		&i.Depth,
+		&i.DeletedAt,
+		&i.EditedAt,
+	)
+	return i, err
+}
@@ -8,38 +8,27 @@ package database
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-const countFollowers = `-- name: CountFollowers :one
-SELECT COUNT(*)::int FROM follows
-WHERE following_id = $1
-`
-
-func (q *Queries) CountFollowers(ctx context.Context, followingID uuid.UUID) (int32, error) {
-	row := q.db.QueryRowContext(ctx, countFollowers, followingID)
-	var column_1 int32
-	err := row.Scan(&column_1)
-	return column_1, err
-}
-
-const countFollowing = `-- name: CountFollowing :one
-SELECT COUNT(*)::int FROM follows
-WHERE follower_id = $1
-`
-
-func (q *Queries) CountFollowing(ctx context.Context, followerID uuid.UUID) (int32, error) {
-	row := q.db.QueryRowContext(ctx, countFollowing, followerID)
-	var column_1 int32
-	err := row.Scan(&column_1)
-	return column_1, err
-}
-
 const followUser = `-- name: FollowUser :exec
-INSERT INTO follows (follower_id, following_id)
-VALUES ($1, $2)
-ON CONFLICT DO NOTHING
+WITH inserted AS (
+    INSERT INTO follows (follower_id, following_id)
+    VALUES ($1, $2)
+    ON CONFLICT DO NOTHING
+    RETURNING follower_id, following_id
+), followers AS (
+    UPDATE users SET follower_count = follower_count + 1
+    WHERE id IN (SELECT following_id FROM inserted)
+), daily AS (
+    INSERT INTO user_daily_stats AS s (user_id, day, followers_gained)
+    SELECT i.following_id, (NOW() AT TIME ZONE 'UTC')::date, 1 FROM inserted i
+    ON CONFLICT (user_id, day) DO UPDATE SET followers_gained = s.followers_gained + 1
+)
+UPDATE users SET following_count = following_count + 1
+WHERE id IN (SELECT follower_id FROM inserted)
 `
 
 type FollowUserParams struct {
@@ -47,6 +36,9 @@ type FollowUserParams struct {
 	FollowingID uuid.UUID
 }
 
+// Creates the follow and updates both users' counters and the followed
+// user's stats rollup in one statement; a repeated follow inserts nothing and
+// leaves the counters alone
 func (q *Queries) FollowUser(ctx context.Context, arg FollowUserParams) error {
 	_, err := q.db.ExecContext(ctx, followUser, arg.FollowerID, arg.FollowingID)
 	return err
@@ -72,30 +64,41 @@ func (q *Queries) IsFollowing(ctx context.Context, arg IsFollowingParams) (bool,
 }
 
 const listFollowers = `-- name: ListFollowers :many
-SELECT u.id, u.username, u.name, u.avatar_url, u.bio
+SELECT u.id, u.username, u.name, u.avatar_url, u.bio, f.created_at AS followed_at
 FROM users u
 JOIN follows f ON f.follower_id = u.id
 WHERE f.following_id = $1
-ORDER BY f.created_at DESC
-LIMIT $2 OFFSET $3
+    AND ($2::timestamp IS NULL
+        OR (f.created_at, u.id) < ($2::timestamp, $3::uuid))
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT $5 OFFSET $4
 `
 
 type ListFollowersParams struct {
 	FollowingID uuid.UUID
-	Limit       int32
+	CursorTime  sql.NullTime
+	CursorID    uuid.NullUUID
 	Offset      int32
+	Limit       int32
 }
 
 type ListFollowersRow struct {
-	ID        uuid.UUID
-	Username  sql.NullString
-	Name      string
-	AvatarUrl string
-	Bio       string
+	ID         uuid.UUID
+	Username   sql.NullString
+	Name       string
+	AvatarUrl  string
+	Bio        string
+	FollowedAt time.Time
 }
 
 func (q *Queries) ListFollowers(ctx context.Context, arg ListFollowersParams) ([]ListFollowersRow, error) {
-	rows, err := q.db.QueryContext(ctx, listFollowers, arg.FollowingID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listFollowers,
+		arg.FollowingID,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +112,7 @@ func (q *Queries) ListFollowers(ctx context.Context, arg ListFollowersParams) ([
 			&i.Name,
 			&i.AvatarUrl,
 			&i.Bio,
+			&i.FollowedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -124,30 +128,41 @@ func (q *Queries) ListFollowers(ctx context.Context, arg ListFollowersParams) ([
 }
 
 const listFollowing = `-- name: ListFollowing :many
-SELECT u.id, u.username, u.name, u.avatar_url, u.bio
+SELECT u.id, u.username, u.name, u.avatar_url, u.bio, f.created_at AS followed_at
 FROM users u
 JOIN follows f ON f.following_id = u.id
 WHERE f.follower_id = $1
-ORDER BY f.created_at DESC
-LIMIT $2 OFFSET $3
+    AND ($2::timestamp IS NULL
+        OR (f.created_at, u.id) < ($2::timestamp, $3::uuid))
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT $5 OFFSET $4
 `
 
 type ListFollowingParams struct {
 	FollowerID uuid.UUID
-	Limit      int32
+	CursorTime sql.NullTime
+	CursorID   uuid.NullUUID
 	Offset     int32
+	Limit      int32
 }
 
 type ListFollowingRow struct {
-	ID        uuid.UUID
-	Username  sql.NullString
-	Name      string
-	AvatarUrl string
-	Bio       string
+	ID         uuid.UUID
+	Username   sql.NullString
+	Name       string
+	AvatarUrl  string
+	Bio        string
+	FollowedAt time.Time
 }
 
 func (q *Queries) ListFollowing(ctx context.Context, arg ListFollowingParams) ([]ListFollowingRow, error) {
-	rows, err := q.db.QueryContext(ctx, listFollowing, arg.FollowerID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listFollowing,
+		arg.FollowerID,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -161,6 +176,7 @@ func (q *Queries) ListFollowing(ctx context.Context, arg ListFollowingParams) ([
 			&i.Name,
 			&i.AvatarUrl,
 			&i.Bio,
+			&i.FollowedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -176,8 +192,20 @@ func (q *Queries) ListFollowing(ctx context.Context, arg ListFollowingParams) ([
 }
 
 const unfollowUser = `-- name: UnfollowUser :exec
-DELETE FROM follows
-WHERE follower_id = $1 AND following_id = $2
+WITH deleted AS (
+    DELETE FROM follows
+    WHERE follower_id = $1 AND following_id = $2
+    RETURNING follower_id, following_id
+), followers AS (
+    UPDATE users SET follower_count = follower_count - 1
+    WHERE id IN (SELECT following_id FROM deleted)
+), daily AS (
+    INSERT INTO user_daily_stats AS s (user_id, day, followers_lost)
+    SELECT d.following_id, (NOW() AT TIME ZONE 'UTC')::date, 1 FROM deleted d
+    ON CONFLICT (user_id, day) DO UPDATE SET followers_lost = s.followers_lost + 1
+)
+UPDATE users SET following_count = following_count - 1
+WHERE id IN (SELECT follower_id FROM deleted)
 `
 
 type UnfollowUserParams struct {
@@ -185,6 +213,8 @@ type UnfollowUserParams struct {
 	FollowingID uuid.UUID
 }
 
+// Removes the follow and updates both users' counters and the followed
+// user's stats rollup in one statement
 func (q *Queries) UnfollowUser(ctx context.Context, arg UnfollowUserParams) error {
 	_, err := q.db.ExecContext(ctx, unfollowUser, arg.FollowerID, arg.FollowingID)
 	return err
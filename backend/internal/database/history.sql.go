@@ -0,0 +1,297 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: history.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const clearReadingHistory = `-- name: ClearReadingHistory :execrows
+DELETE FROM article_reads
+WHERE user_id = $1
+`
+
+func (q *Queries) ClearReadingHistory(ctx context.Context, userID uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, clearReadingHistory, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteReadingHistoryItem = `-- name: DeleteReadingHistoryItem :execrows
+DELETE FROM article_reads
+WHERE user_id = $1 AND article_id = $2
+`
+
+type DeleteReadingHistoryItemParams struct {
+	UserID    uuid.UUID
+	ArticleID uuid.UUID
+}
+
+func (q *Queries) DeleteReadingHistoryItem(ctx context.Context, arg DeleteReadingHistoryItemParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteReadingHistoryItem, arg.UserID, arg.ArticleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getHistoryPaused = `-- name: GetHistoryPaused :one
+SELECT history_paused FROM users
+WHERE id = $1
+`
+
+func (q *Queries) GetHistoryPaused(ctx context.Context, id uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, getHistoryPaused, id)
+	var history_paused bool
+	err := row.Scan(&history_paused)
+	return history_paused, err
+}
+
+const getReadingPosition = `-- name: GetReadingPosition :one
+SELECT progress, finished_at, read_at FROM article_reads
+WHERE user_id = $1 AND article_id = $2
+`
+
+type GetReadingPositionParams struct {
+	UserID    uuid.UUID
+	ArticleID uuid.UUID
+}
+
+type GetReadingPositionRow struct {
+	Progress   float32
+	FinishedAt sql.NullTime
+	ReadAt     time.Time
+}
+
+func (q *Queries) GetReadingPosition(ctx context.Context, arg GetReadingPositionParams) (GetReadingPositionRow, error) {
+	row := q.db.QueryRowContext(ctx, getReadingPosition, arg.UserID, arg.ArticleID)
+	var i GetReadingPositionRow
+	err := row.Scan(&i.Progress, &i.FinishedAt, &i.ReadAt)
+	return i, err
+}
+
+const listReadingHistory = `-- name: ListReadingHistory :many
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN $1::bool THEN a.body ELSE '' END)::text AS body,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug,
+    r.progress,
+    r.finished_at,
+    r.first_opened_at,
+    r.read_at AS last_read_at
+FROM article_reads r
+JOIN articles a ON r.article_id = a.id
+JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE r.user_id = $2 AND a.status = 'published'
+    AND ($3::timestamp IS NULL
+        OR (r.read_at, a.id) < ($3::timestamp, $4::uuid))
+ORDER BY r.read_at DESC, a.id DESC
+LIMIT $6 OFFSET $5
+`
+
+type ListReadingHistoryParams struct {
+	IncludeBody bool
+	UserID      uuid.UUID
+	CursorTime  sql.NullTime
+	CursorID    uuid.NullUUID
+	Offset      int32
+	Limit       int32
+}
+
+type ListReadingHistoryRow struct {
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	CommentCount        int32
+	Visibility          string
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	Body                string
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	TotalClaps          int32
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
+	Progress            float32
+	FinishedAt          sql.NullTime
+	FirstOpenedAt       time.Time
+	LastReadAt          time.Time
+}
+
+// Published articles a reader has opened, most recently opened first
+func (q *Queries) ListReadingHistory(ctx context.Context, arg ListReadingHistoryParams) ([]ListReadingHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, listReadingHistory,
+		arg.IncludeBody,
+		arg.UserID,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListReadingHistoryRow
+	for rows.Next() {
+		var i ListReadingHistoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Summary,
+			&i.ThumbnailUrl,
+			&i.Status,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.Body,
+			&i.AuthorUsername,
+			&i.AuthorName,
+			&i.AuthorAvatarUrl,
+			&i.TotalClaps,
+			&i.PublicationName,
+			&i.PublicationSlug,
+			&i.Progress,
+			&i.FinishedAt,
+			&i.FirstOpenedAt,
+			&i.LastReadAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordArticleOpen = `-- name: RecordArticleOpen :execrows
+INSERT INTO article_reads AS ar (user_id, article_id)
+SELECT u.id, $1 FROM users u
+WHERE u.id = $2 AND NOT u.history_paused
+ON CONFLICT (user_id, article_id) DO UPDATE SET read_at = NOW()
+`
+
+type RecordArticleOpenParams struct {
+	ArticleID uuid.UUID
+	UserID    uuid.UUID
+}
+
+// Adds an article a reader opened to their history, unless it is paused
+func (q *Queries) RecordArticleOpen(ctx context.Context, arg RecordArticleOpenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, recordArticleOpen, arg.ArticleID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const recordReadingProgress = `-- name: RecordReadingProgress :execrows
+INSERT INTO article_reads AS ar (user_id, article_id, progress, finished_at)
+SELECT u.id, $1, $2::real,
+    (CASE WHEN $2::real >= $3::real THEN NOW() END)::timestamp
+FROM users u
+WHERE u.id = $4 AND NOT u.history_paused
+ON CONFLICT (user_id, article_id) DO UPDATE
+SET progress = EXCLUDED.progress, read_at = NOW(),
+    finished_at = COALESCE(ar.finished_at, EXCLUDED.finished_at)
+`
+
+type RecordReadingProgressParams struct {
+	ArticleID        uuid.UUID
+	Progress         float32
+	FinishedProgress float32
+	UserID           uuid.UUID
+}
+
+// Saves how far through an article a reader is, unless their history is
+// paused. The article counts as finished once progress reaches
+// finished_progress and stays finished when they scroll back.
+func (q *Queries) RecordReadingProgress(ctx context.Context, arg RecordReadingProgressParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, recordReadingProgress,
+		arg.ArticleID,
+		arg.Progress,
+		arg.FinishedProgress,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const recordUserRead = `-- name: RecordUserRead :exec
+INSERT INTO article_reads AS ar (user_id, article_id, finished_at)
+SELECT u.id, $1, NOW() FROM users u
+WHERE u.id = $2 AND NOT u.history_paused
+ON CONFLICT (user_id, article_id) DO UPDATE
+SET read_at = NOW(), finished_at = COALESCE(ar.finished_at, NOW())
+`
+
+type RecordUserReadParams struct {
+	ArticleID uuid.UUID
+	UserID    uuid.UUID
+}
+
+// Marks an article finished when the read beacon counts a read, unless the
+// reader's history is paused
+func (q *Queries) RecordUserRead(ctx context.Context, arg RecordUserReadParams) error {
+	_, err := q.db.ExecContext(ctx, recordUserRead, arg.ArticleID, arg.UserID)
+	return err
+}
+
+const setHistoryPaused = `-- name: SetHistoryPaused :exec
+UPDATE users
+SET history_paused = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetHistoryPausedParams struct {
+	ID            uuid.UUID
+	HistoryPaused bool
+}
+
+func (q *Queries) SetHistoryPaused(ctx context.Context, arg SetHistoryPausedParams) error {
+	_, err := q.db.ExecContext(ctx, setHistoryPaused, arg.ID, arg.HistoryPaused)
+	return err
+}
@@ -0,0 +1,261 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: imports.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const createImportJob = `-- name: CreateImportJob :one
+INSERT INTO import_jobs (user_id, source, dry_run)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, source, dry_run, status, report, error, created_at, finished_at
+`
+
+type CreateImportJobParams struct {
+	UserID uuid.UUID
+	Source string
+	DryRun bool
+}
+
+func (q *Queries) CreateImportJob(ctx context.Context, arg CreateImportJobParams) (ImportJob, error) {
+	row := q.db.QueryRowContext(ctx, createImportJob, arg.UserID, arg.Source, arg.DryRun)
+	var i ImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Source,
+		&i.DryRun,
+		&i.Status,
+		&i.Report,
+		&i.Error,
+		&i.CreatedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const createImportedArticle = `-- name: CreateImportedArticle :one
+INSERT INTO articles (user_id, title, body, summary, status, published_at, import_key,
+    canonical_url, original_published_at, language)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (user_id, import_key) WHERE import_key IS NOT NULL DO NOTHING
+RETURNING id, user_id, title, body, summary, thumbnail_url, status, published_at, created_at, updated_at, publication_id, revision, reading_time, clap_count, clapper_count, comment_count, visibility, slug, import_key, canonical_url, original_published_at, language, search_vector, translation_group_id
+`
+
+type CreateImportedArticleParams struct {
+	UserID              uuid.UUID
+	Title               string
+	Body                string
+	Summary             string
+	Status              string
+	PublishedAt         sql.NullTime
+	ImportKey           sql.NullString
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+}
+
+// Unlike CreateArticle and PublishArticle, keeps the original publish date
+func (q *Queries) CreateImportedArticle(ctx context.Context, arg CreateImportedArticleParams) (Article, error) {
+	row := q.db.QueryRowContext(ctx, createImportedArticle,
+		arg.UserID,
+		arg.Title,
+		arg.Body,
+		arg.Summary,
+		arg.Status,
+		arg.PublishedAt,
+		arg.ImportKey,
+		arg.CanonicalUrl,
+		arg.OriginalPublishedAt,
+		arg.Language,
+	)
+	var i Article
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Body,
+		&i.Summary,
+		&i.ThumbnailUrl,
+		&i.Status,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublicationID,
+		&i.Revision,
+		&i.ReadingTime,
+		&i.ClapCount,
+		&i.ClapperCount,
+		&i.CommentCount,
+		&i.Visibility,
+		&i.Slug,
+		&i.ImportKey,
+		&i.CanonicalUrl,
+		&i.OriginalPublishedAt,
+		&i.Language,
+		&i.SearchVector,
+		&i.TranslationGroupID,
+	)
+	return i, err
+}
+
+const failInterruptedImportJobs = `-- name: FailInterruptedImportJobs :execrows
+UPDATE import_jobs
+SET status = 'failed', error = 'Import was interrupted by a server restart; please upload the file again',
+    finished_at = NOW()
+WHERE status IN ('pending', 'running')
+`
+
+// Jobs run in the server process, so any still pending or running when it
+// starts were cut off by a restart
+func (q *Queries) FailInterruptedImportJobs(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, failInterruptedImportJobs)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const finishImportJob = `-- name: FinishImportJob :exec
+UPDATE import_jobs
+SET status = $2, report = $3, error = $4, finished_at = NOW()
+WHERE id = $1
+`
+
+type FinishImportJobParams struct {
+	ID     uuid.UUID
+	Status string
+	Report json.RawMessage
+	Error  string
+}
+
+func (q *Queries) FinishImportJob(ctx context.Context, arg FinishImportJobParams) error {
+	_, err := q.db.ExecContext(ctx, finishImportJob,
+		arg.ID,
+		arg.Status,
+		arg.Report,
+		arg.Error,
+	)
+	return err
+}
+
+const getImportJob = `-- name: GetImportJob :one
+SELECT id, user_id, source, dry_run, status, report, error, created_at, finished_at FROM import_jobs
+WHERE id = $1 AND user_id = $2
+`
+
+type GetImportJobParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) GetImportJob(ctx context.Context, arg GetImportJobParams) (ImportJob, error) {
+	row := q.db.QueryRowContext(ctx, getImportJob, arg.ID, arg.UserID)
+	var i ImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Source,
+		&i.DryRun,
+		&i.Status,
+		&i.Report,
+		&i.Error,
+		&i.CreatedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const listImportJobs = `-- name: ListImportJobs :many
+SELECT id, user_id, source, dry_run, status, report, error, created_at, finished_at FROM import_jobs
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 20
+`
+
+func (q *Queries) ListImportJobs(ctx context.Context, userID uuid.UUID) ([]ImportJob, error) {
+	rows, err := q.db.QueryContext(ctx, listImportJobs, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ImportJob
+	for rows.Next() {
+		var i ImportJob
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Source,
+			&i.DryRun,
+			&i.Status,
+			&i.Report,
+			&i.Error,
+			&i.CreatedAt,
+			&i.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listImportKeys = `-- name: ListImportKeys :many
+SELECT import_key::text FROM articles
+WHERE user_id = $1 AND import_key = ANY($2::text[])
+`
+
+type ListImportKeysParams struct {
+	UserID     uuid.UUID
+	ImportKeys []string
+}
+
+// Import keys the user has already imported, out of the given ones
+func (q *Queries) ListImportKeys(ctx context.Context, arg ListImportKeysParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listImportKeys, arg.UserID, pq.Array(arg.ImportKeys))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var import_key string
+		if err := rows.Scan(&import_key); err != nil {
+			return nil, err
+		}
+		items = append(items, import_key)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const startImportJob = `-- name: StartImportJob :exec
+UPDATE import_jobs
+SET status = 'running'
+WHERE id = $1
+`
+
+func (q *Queries) StartImportJob(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, startImportJob, id)
+	return err
+}
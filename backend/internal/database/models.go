@@ -6,23 +6,118 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Article struct {
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Body                string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	ClapCount           int32
+	ClapperCount        int32
+	CommentCount        int32
+	Visibility          string
+	Slug                sql.NullString
+	ImportKey           sql.NullString
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	SearchVector        interface{}
+	TranslationGroupID  uuid.NullUUID
+}
+
+type ArticleCollaborator struct {
+	ArticleID  uuid.UUID
+	UserID     uuid.UUID
+	Role       string
+	InvitedBy  uuid.UUID
+	AcceptedAt sql.NullTime
+	CreatedAt  time.Time
+}
+
+type ArticleDailyStat struct {
+	ArticleID uuid.UUID
+	Day       time.Time
+	Views     int32
+	Reads     int32
+	Claps     int32
+	Clappers  int32
+	Comments  int32
+}
+
+type ArticleHighlight struct {
+	ID          uuid.UUID
+	ArticleID   uuid.UUID
+	UserID      uuid.UUID
+	AnchorStart int32
+	AnchorEnd   int32
+	Quote       string
+	Note        string
+	Detached    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ArticleNote struct {
 	ID           uuid.UUID
-	UserID       uuid.UUID
-	Title        string
+	ArticleID    uuid.UUID
+	UserID       uuid.NullUUID
+	ShareLinkID  uuid.NullUUID
+	ReviewerName string
 	Body         string
-	Summary      string
-	ThumbnailUrl string
-	Status       string
-	PublishedAt  sql.NullTime
+	AnchorStart  int32
+	AnchorEnd    int32
+	Quote        string
+	Detached     bool
+	ResolvedAt   sql.NullTime
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
-	SearchVector interface{}
+}
+
+type ArticleRead struct {
+	UserID        uuid.UUID
+	ArticleID     uuid.UUID
+	ReadAt        time.Time
+	FirstOpenedAt time.Time
+	Progress      float32
+	FinishedAt    sql.NullTime
+}
+
+type ArticleRelatedCache struct {
+	ArticleID  uuid.UUID
+	RelatedIds []uuid.UUID
+	ComputedAt time.Time
+}
+
+type ArticleScore struct {
+	ArticleID  uuid.UUID
+	TimeWindow string
+	Trending   float64
+	Top        float64
+	ComputedAt time.Time
+}
+
+type ArticleShareLink struct {
+	ID        uuid.UUID
+	ArticleID uuid.UUID
+	CreatedBy uuid.UUID
+	Label     string
+	ExpiresAt sql.NullTime
+	RevokedAt sql.NullTime
+	CreatedAt time.Time
 }
 
 type ArticleTag struct {
@@ -30,6 +125,14 @@ type ArticleTag struct {
 	TagID     uuid.UUID
 }
 
+type ArticleView struct {
+	ArticleID  uuid.UUID
+	Day        time.Time
+	ViewerHash string
+	ReadAt     sql.NullTime
+	CreatedAt  time.Time
+}
+
 type Clap struct {
 	ID        uuid.UUID
 	ArticleID uuid.UUID
@@ -46,6 +149,17 @@ type Comment struct {
 	Body      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	ParentID  uuid.NullUUID
+	Depth     int32
+	DeletedAt sql.NullTime
+	EditedAt  sql.NullTime
+}
+
+type CommentEdit struct {
+	ID         uuid.UUID
+	CommentID  uuid.UUID
+	Body       string
+	ReplacedAt time.Time
 }
 
 type Follow struct {
@@ -54,6 +168,90 @@ type Follow struct {
 	CreatedAt   time.Time
 }
 
+type ImportJob struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Source     string
+	DryRun     bool
+	Status     string
+	Report     json.RawMessage
+	Error      string
+	CreatedAt  time.Time
+	FinishedAt sql.NullTime
+}
+
+type MutedAuthor struct {
+	UserID      uuid.UUID
+	MutedUserID uuid.UUID
+	CreatedAt   time.Time
+}
+
+type MutedKeyword struct {
+	UserID    uuid.UUID
+	Keyword   string
+	CreatedAt time.Time
+}
+
+type MutedTag struct {
+	UserID    uuid.UUID
+	TagID     uuid.UUID
+	CreatedAt time.Time
+}
+
+type Publication struct {
+	ID          uuid.UUID
+	Slug        string
+	Name        string
+	Description string
+	AvatarUrl   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type PublicationFollow struct {
+	FollowerID    uuid.UUID
+	PublicationID uuid.UUID
+	CreatedAt     time.Time
+}
+
+type PublicationMember struct {
+	PublicationID uuid.UUID
+	UserID        uuid.UUID
+	Role          string
+	CreatedAt     time.Time
+}
+
+type PublicationSubmission struct {
+	ID            uuid.UUID
+	PublicationID uuid.UUID
+	ArticleID     uuid.UUID
+	SubmittedBy   uuid.UUID
+	Status        string
+	Note          string
+	ReviewerID    uuid.NullUUID
+	ReviewNote    string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type ReadingList struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	IsPublic    bool
+	IsDefault   bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ReadingListItem struct {
+	ListID    uuid.UUID
+	ArticleID uuid.UUID
+	Position  int32
+	AddedAt   time.Time
+}
+
 type RefreshToken struct {
 	Token     string
 	UserID    uuid.UUID
@@ -78,4 +276,17 @@ type User struct {
 	Name           string
 	Bio            string
 	AvatarUrl      string
+	Revision       int32
+	FollowerCount  int32
+	FollowingCount int32
+	IsAdmin        bool
+	IsMember       bool
+	HistoryPaused  bool
+}
+
+type UserDailyStat struct {
+	UserID          uuid.UUID
+	Day             time.Time
+	FollowersGained int32
+	FollowersLost   int32
 }
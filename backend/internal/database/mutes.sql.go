@@ -0,0 +1,245 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: mutes.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const countMutedKeywords = `-- name: CountMutedKeywords :one
+SELECT COUNT(*)::int FROM muted_keywords
+WHERE user_id = $1
+`
+
+func (q *Queries) CountMutedKeywords(ctx context.Context, userID uuid.UUID) (int32, error) {
+	row := q.db.QueryRowContext(ctx, countMutedKeywords, userID)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const listMutedAuthors = `-- name: ListMutedAuthors :many
+SELECT u.id, u.username, u.name, u.avatar_url, ma.created_at AS muted_at
+FROM muted_authors ma
+JOIN users u ON u.id = ma.muted_user_id
+WHERE ma.user_id = $1
+ORDER BY ma.created_at DESC
+`
+
+type ListMutedAuthorsRow struct {
+	ID        uuid.UUID
+	Username  sql.NullString
+	Name      string
+	AvatarUrl string
+	MutedAt   time.Time
+}
+
+func (q *Queries) ListMutedAuthors(ctx context.Context, userID uuid.UUID) ([]ListMutedAuthorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMutedAuthors, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMutedAuthorsRow
+	for rows.Next() {
+		var i ListMutedAuthorsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Name,
+			&i.AvatarUrl,
+			&i.MutedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMutedKeywords = `-- name: ListMutedKeywords :many
+SELECT keyword, created_at AS muted_at
+FROM muted_keywords
+WHERE user_id = $1
+ORDER BY created_at DESC
+`
+
+type ListMutedKeywordsRow struct {
+	Keyword string
+	MutedAt time.Time
+}
+
+func (q *Queries) ListMutedKeywords(ctx context.Context, userID uuid.UUID) ([]ListMutedKeywordsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMutedKeywords, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMutedKeywordsRow
+	for rows.Next() {
+		var i ListMutedKeywordsRow
+		if err := rows.Scan(&i.Keyword, &i.MutedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMutedTags = `-- name: ListMutedTags :many
+SELECT t.name, mt.created_at AS muted_at
+FROM muted_tags mt
+JOIN tags t ON t.id = mt.tag_id
+WHERE mt.user_id = $1
+ORDER BY mt.created_at DESC
+`
+
+type ListMutedTagsRow struct {
+	Name    string
+	MutedAt time.Time
+}
+
+func (q *Queries) ListMutedTags(ctx context.Context, userID uuid.UUID) ([]ListMutedTagsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMutedTags, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMutedTagsRow
+	for rows.Next() {
+		var i ListMutedTagsRow
+		if err := rows.Scan(&i.Name, &i.MutedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const muteAuthor = `-- name: MuteAuthor :exec
+INSERT INTO muted_authors (user_id, muted_user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type MuteAuthorParams struct {
+	UserID      uuid.UUID
+	MutedUserID uuid.UUID
+}
+
+func (q *Queries) MuteAuthor(ctx context.Context, arg MuteAuthorParams) error {
+	_, err := q.db.ExecContext(ctx, muteAuthor, arg.UserID, arg.MutedUserID)
+	return err
+}
+
+const muteKeyword = `-- name: MuteKeyword :exec
+INSERT INTO muted_keywords (user_id, keyword)
+VALUES ($1, LOWER($2))
+ON CONFLICT DO NOTHING
+`
+
+type MuteKeywordParams struct {
+	UserID  uuid.UUID
+	Keyword string
+}
+
+func (q *Queries) MuteKeyword(ctx context.Context, arg MuteKeywordParams) error {
+	_, err := q.db.ExecContext(ctx, muteKeyword, arg.UserID, arg.Keyword)
+	return err
+}
+
+const muteTag = `-- name: MuteTag :exec
+INSERT INTO muted_tags (user_id, tag_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type MuteTagParams struct {
+	UserID uuid.UUID
+	TagID  uuid.UUID
+}
+
+func (q *Queries) MuteTag(ctx context.Context, arg MuteTagParams) error {
+	_, err := q.db.ExecContext(ctx, muteTag, arg.UserID, arg.TagID)
+	return err
+}
+
+const unmuteAuthor = `-- name: UnmuteAuthor :execrows
+DELETE FROM muted_authors
+WHERE user_id = $1 AND muted_user_id = $2
+`
+
+type UnmuteAuthorParams struct {
+	UserID      uuid.UUID
+	MutedUserID uuid.UUID
+}
+
+func (q *Queries) UnmuteAuthor(ctx context.Context, arg UnmuteAuthorParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unmuteAuthor, arg.UserID, arg.MutedUserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const unmuteKeyword = `-- name: UnmuteKeyword :execrows
+DELETE FROM muted_keywords
+WHERE user_id = $1 AND keyword = LOWER($2)
+`
+
+type UnmuteKeywordParams struct {
+	UserID  uuid.UUID
+	Keyword string
+}
+
+func (q *Queries) UnmuteKeyword(ctx context.Context, arg UnmuteKeywordParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unmuteKeyword, arg.UserID, arg.Keyword)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const unmuteTag = `-- name: UnmuteTag :execrows
+DELETE FROM muted_tags mt
+USING tags t
+WHERE mt.tag_id = t.id AND mt.user_id = $1 AND t.name = LOWER($2)
+`
+
+type UnmuteTagParams struct {
+	UserID  uuid.UUID
+	TagName string
+}
+
+func (q *Queries) UnmuteTag(ctx context.Context, arg UnmuteTagParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unmuteTag, arg.UserID, arg.TagName)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
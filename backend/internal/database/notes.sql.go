@@ -0,0 +1,261 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: notes.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const createArticleNote = `-- name: CreateArticleNote :one
+INSERT INTO article_notes (article_id, user_id, share_link_id, reviewer_name, body, anchor_start, anchor_end, quote)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, article_id, user_id, share_link_id, reviewer_name, body, anchor_start, anchor_end, quote, detached, resolved_at, created_at, updated_at
+`
+
+type CreateArticleNoteParams struct {
+	ArticleID    uuid.UUID
+	UserID       uuid.NullUUID
+	ShareLinkID  uuid.NullUUID
+	ReviewerName string
+	Body         string
+	AnchorStart  int32
+	AnchorEnd    int32
+	Quote        string
+}
+
+func (q *Queries) CreateArticleNote(ctx context.Context, arg CreateArticleNoteParams) (ArticleNote, error) {
+	row := q.db.QueryRowContext(ctx, createArticleNote,
+		arg.ArticleID,
+		arg.UserID,
+		arg.ShareLinkID,
+		arg.ReviewerName,
+		arg.Body,
+		arg.AnchorStart,
+		arg.AnchorEnd,
+		arg.Quote,
+	)
+	var i ArticleNote
+	err := row.Scan(
+		&i.ID,
+		&i.ArticleID,
+		&i.UserID,
+		&i.ShareLinkID,
+		&i.ReviewerName,
+		&i.Body,
+		&i.AnchorStart,
+		&i.AnchorEnd,
+		&i.Quote,
+		&i.Detached,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteArticleNote = `-- name: DeleteArticleNote :exec
+DELETE FROM article_notes
+WHERE id = $1
+`
+
+func (q *Queries) DeleteArticleNote(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteArticleNote, id)
+	return err
+}
+
+const getArticleNote = `-- name: GetArticleNote :one
+SELECT n.id, n.article_id, n.user_id, n.share_link_id, n.reviewer_name, n.body, n.anchor_start, n.anchor_end, n.quote, n.detached, n.resolved_at, n.created_at, n.updated_at,
+    u.username AS author_username,
+    u.name AS author_name
+FROM article_notes n
+LEFT JOIN users u ON n.user_id = u.id
+WHERE n.id = $1
+`
+
+type GetArticleNoteRow struct {
+	ArticleNote    ArticleNote
+	AuthorUsername sql.NullString
+	AuthorName     sql.NullString
+}
+
+func (q *Queries) GetArticleNote(ctx context.Context, id uuid.UUID) (GetArticleNoteRow, error) {
+	row := q.db.QueryRowContext(ctx, getArticleNote, id)
+	var i GetArticleNoteRow
+	err := row.Scan(
+		&i.ArticleNote.ID,
+		&i.ArticleNote.ArticleID,
+		&i.ArticleNote.UserID,
+		&i.ArticleNote.ShareLinkID,
+		&i.ArticleNote.ReviewerName,
+		&i.ArticleNote.Body,
+		&i.ArticleNote.AnchorStart,
+		&i.ArticleNote.AnchorEnd,
+		&i.ArticleNote.Quote,
+		&i.ArticleNote.Detached,
+		&i.ArticleNote.ResolvedAt,
+		&i.ArticleNote.CreatedAt,
+		&i.ArticleNote.UpdatedAt,
+		&i.AuthorUsername,
+		&i.AuthorName,
+	)
+	return i, err
+}
+
+const listArticleNotes = `-- name: ListArticleNotes :many
+SELECT n.id, n.article_id, n.user_id, n.share_link_id, n.reviewer_name, n.body, n.anchor_start, n.anchor_end, n.quote, n.detached, n.resolved_at, n.created_at, n.updated_at,
+    u.username AS author_username,
+    u.name AS author_name
+FROM article_notes n
+LEFT JOIN users u ON n.user_id = u.id
+WHERE n.article_id = $1
+ORDER BY n.detached ASC, n.anchor_start ASC, n.created_at ASC
+`
+
+type ListArticleNotesRow struct {
+	ArticleNote    ArticleNote
+	AuthorUsername sql.NullString
+	AuthorName     sql.NullString
+}
+
+func (q *Queries) ListArticleNotes(ctx context.Context, articleID uuid.UUID) ([]ListArticleNotesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listArticleNotes, articleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArticleNotesRow
+	for rows.Next() {
+		var i ListArticleNotesRow
+		if err := rows.Scan(
+			&i.ArticleNote.ID,
+			&i.ArticleNote.ArticleID,
+			&i.ArticleNote.UserID,
+			&i.ArticleNote.ShareLinkID,
+			&i.ArticleNote.ReviewerName,
+			&i.ArticleNote.Body,
+			&i.ArticleNote.AnchorStart,
+			&i.ArticleNote.AnchorEnd,
+			&i.ArticleNote.Quote,
+			&i.ArticleNote.Detached,
+			&i.ArticleNote.ResolvedAt,
+			&i.ArticleNote.CreatedAt,
+			&i.ArticleNote.UpdatedAt,
+			&i.AuthorUsername,
+			&i.AuthorName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNoteAnchors = `-- name: ListNoteAnchors :many
+SELECT id, anchor_start, anchor_end, quote
+FROM article_notes
+WHERE article_id = $1 AND NOT detached
+`
+
+type ListNoteAnchorsRow struct {
+	ID          uuid.UUID
+	AnchorStart int32
+	AnchorEnd   int32
+	Quote       string
+}
+
+func (q *Queries) ListNoteAnchors(ctx context.Context, articleID uuid.UUID) ([]ListNoteAnchorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listNoteAnchors, articleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNoteAnchorsRow
+	for rows.Next() {
+		var i ListNoteAnchorsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AnchorStart,
+			&i.AnchorEnd,
+			&i.Quote,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setArticleNoteResolved = `-- name: SetArticleNoteResolved :one
+UPDATE article_notes
+SET resolved_at = CASE WHEN $1::bool THEN NOW() ELSE NULL END,
+    updated_at = NOW()
+WHERE id = $2
+RETURNING id, article_id, user_id, share_link_id, reviewer_name, body, anchor_start, anchor_end, quote, detached, resolved_at, created_at, updated_at
+`
+
+type SetArticleNoteResolvedParams struct {
+	Resolved bool
+	ID       uuid.UUID
+}
+
+func (q *Queries) SetArticleNoteResolved(ctx context.Context, arg SetArticleNoteResolvedParams) (ArticleNote, error) {
+	row := q.db.QueryRowContext(ctx, setArticleNoteResolved, arg.Resolved, arg.ID)
+	var i ArticleNote
+	err := row.Scan(
+		&i.ID,
+		&i.ArticleID,
+		&i.UserID,
+		&i.ShareLinkID,
+		&i.ReviewerName,
+		&i.Body,
+		&i.AnchorStart,
+		&i.AnchorEnd,
+		&i.Quote,
+		&i.Detached,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateNoteAnchor = `-- name: UpdateNoteAnchor :exec
+UPDATE article_notes
+SET anchor_start = $2, anchor_end = $3, detached = $4, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateNoteAnchorParams struct {
+	ID          uuid.UUID
+	AnchorStart int32
+	AnchorEnd   int32
+	Detached    bool
+}
+
+func (q *Queries) UpdateNoteAnchor(ctx context.Context, arg UpdateNoteAnchorParams) error {
+	_, err := q.db.ExecContext(ctx, updateNoteAnchor,
+		arg.ID,
+		arg.AnchorStart,
+		arg.AnchorEnd,
+		arg.Detached,
+	)
+	return err
+}
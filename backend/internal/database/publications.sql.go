@@ -0,0 +1,625 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: publications.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const acceptSubmission = `-- name: AcceptSubmission :one
+WITH pending AS (
+    SELECT s.id, s.publication_id, s.article_id FROM publication_submissions s
+    WHERE s.id = $2 AND s.status = 'pending'
+    FOR UPDATE
+), attached AS (
+    UPDATE articles a
+    SET publication_id = p.publication_id, updated_at = NOW()
+    FROM pending p
+    WHERE a.id = p.article_id AND a.status = 'draft' AND a.publication_id IS NULL
+    RETURNING a.id
+)
+UPDATE publication_submissions s
+SET status = 'accepted', reviewer_id = $1, updated_at = NOW()
+FROM attached t
+WHERE s.id = $2 AND s.article_id = t.id
+RETURNING s.id, s.publication_id, s.article_id, s.submitted_by, s.status, s.note, s.reviewer_id, s.review_note, s.created_at, s.updated_at
+`
+
+type AcceptSubmissionParams struct {
+	ReviewerID uuid.NullUUID
+	ID         uuid.UUID
+}
+
+// Accepts a pending submission and attaches its article to the publication in
+// one statement. Nothing is returned when the submission is no longer pending
+// or the article is no longer a draft outside any publication.
+func (q *Queries) AcceptSubmission(ctx context.Context, arg AcceptSubmissionParams) (PublicationSubmission, error) {
+	row := q.db.QueryRowContext(ctx, acceptSubmission, arg.ReviewerID, arg.ID)
+	var i PublicationSubmission
+	err := row.Scan(
+		&i.ID,
+		&i.PublicationID,
+		&i.ArticleID,
+		&i.SubmittedBy,
+		&i.Status,
+		&i.Note,
+		&i.ReviewerID,
+		&i.ReviewNote,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const addPublicationMember = `-- name: AddPublicationMember :exec
+INSERT INTO publication_members (publication_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (publication_id, user_id) DO UPDATE
+SET role = EXCLUDED.role
+`
+
+type AddPublicationMemberParams struct {
+	PublicationID uuid.UUID
+	UserID        uuid.UUID
+	Role          string
+}
+
+func (q *Queries) AddPublicationMember(ctx context.Context, arg AddPublicationMemberParams) error {
+	_, err := q.db.ExecContext(ctx, addPublicationMember, arg.PublicationID, arg.UserID, arg.Role)
+	return err
+}
+
+const countPublicationFollowers = `-- name: CountPublicationFollowers :one
+SELECT COUNT(*)::int FROM publication_follows
+WHERE publication_id = $1
+`
+
+func (q *Queries) CountPublicationFollowers(ctx context.Context, publicationID uuid.UUID) (int32, error) {
+	row := q.db.QueryRowContext(ctx, countPublicationFollowers, publicationID)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createPublication = `-- name: CreatePublication :one
+WITH created AS (
+    INSERT INTO publications (slug, name, description, avatar_url)
+    VALUES (LOWER($1), $2, $3, $4)
+    RETURNING id, slug, name, description, avatar_url, created_at, updated_at
+), owner AS (
+    INSERT INTO publication_members (publication_id, user_id, role)
+    SELECT c.id, $5, 'owner' FROM created c
+)
+SELECT id, slug, name, description, avatar_url, created_at, updated_at FROM created
+`
+
+type CreatePublicationParams struct {
+	Slug        string
+	Name        string
+	Description string
+	AvatarUrl   string
+	OwnerID     uuid.UUID
+}
+
+type CreatePublicationRow struct {
+	ID          uuid.UUID
+	Slug        string
+	Name        string
+	Description string
+	AvatarUrl   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Creates a publication with its creator as owner in one statement
+func (q *Queries) CreatePublication(ctx context.Context, arg CreatePublicationParams) (CreatePublicationRow, error) {
+	row := q.db.QueryRowContext(ctx, createPublication,
+		arg.Slug,
+		arg.Name,
+		arg.Description,
+		arg.AvatarUrl,
+		arg.OwnerID,
+	)
+	var i CreatePublicationRow
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.Description,
+		&i.AvatarUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const followPublication = `-- name: FollowPublication :exec
+INSERT INTO publication_follows (follower_id, publication_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type FollowPublicationParams struct {
+	FollowerID    uuid.UUID
+	PublicationID uuid.UUID
+}
+
+func (q *Queries) FollowPublication(ctx context.Context, arg FollowPublicationParams) error {
+	_, err := q.db.ExecContext(ctx, followPublication, arg.FollowerID, arg.PublicationID)
+	return err
+}
+
+const getPublicationBySlug = `-- name: GetPublicationBySlug :one
+SELECT id, slug, name, description, avatar_url, created_at, updated_at FROM publications
+WHERE slug = LOWER($1)
+`
+
+func (q *Queries) GetPublicationBySlug(ctx context.Context, slug string) (Publication, error) {
+	row := q.db.QueryRowContext(ctx, getPublicationBySlug, slug)
+	var i Publication
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.Description,
+		&i.AvatarUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPublicationMemberRole = `-- name: GetPublicationMemberRole :one
+SELECT role FROM publication_members
+WHERE publication_id = $1 AND user_id = $2
+`
+
+type GetPublicationMemberRoleParams struct {
+	PublicationID uuid.UUID
+	UserID        uuid.UUID
+}
+
+func (q *Queries) GetPublicationMemberRole(ctx context.Context, arg GetPublicationMemberRoleParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getPublicationMemberRole, arg.PublicationID, arg.UserID)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
+const getSubmissionByID = `-- name: GetSubmissionByID :one
+SELECT id, publication_id, article_id, submitted_by, status, note, reviewer_id, review_note, created_at, updated_at FROM publication_submissions
+WHERE id = $1
+`
+
+func (q *Queries) GetSubmissionByID(ctx context.Context, id uuid.UUID) (PublicationSubmission, error) {
+	row := q.db.QueryRowContext(ctx, getSubmissionByID, id)
+	var i PublicationSubmission
+	err := row.Scan(
+		&i.ID,
+		&i.PublicationID,
+		&i.ArticleID,
+		&i.SubmittedBy,
+		&i.Status,
+		&i.Note,
+		&i.ReviewerID,
+		&i.ReviewNote,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const hasOpenSubmission = `-- name: HasOpenSubmission :one
+SELECT EXISTS (
+    SELECT 1 FROM publication_submissions
+    WHERE article_id = $1 AND status IN ('pending', 'changes_requested', 'accepted')
+)::bool
+`
+
+// Whether an article is in a publication's review or accepted and waiting for
+// its editors to publish it
+func (q *Queries) HasOpenSubmission(ctx context.Context, articleID uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, hasOpenSubmission, articleID)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const listArticlesByPublication = `-- name: ListArticlesByPublication :many
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN $1::bool THEN a.body ELSE '' END)::text AS body,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
+FROM articles a
+JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE a.publication_id = $2 AND a.status = 'published'
+    AND ($3::timestamp IS NULL
+        OR (a.published_at, a.id) < ($3::timestamp, $4::uuid))
+ORDER BY a.published_at DESC, a.id DESC
+LIMIT $6 OFFSET $5
+`
+
+type ListArticlesByPublicationParams struct {
+	IncludeBody   bool
+	PublicationID uuid.NullUUID
+	CursorTime    sql.NullTime
+	CursorID      uuid.NullUUID
+	Offset        int32
+	Limit         int32
+}
+
+type ListArticlesByPublicationRow struct {
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	CommentCount        int32
+	Visibility          string
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	Body                string
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	TotalClaps          int32
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
+}
+
+func (q *Queries) ListArticlesByPublication(ctx context.Context, arg ListArticlesByPublicationParams) ([]ListArticlesByPublicationRow, error) {
+	rows, err := q.db.QueryContext(ctx, listArticlesByPublication,
+		arg.IncludeBody,
+		arg.PublicationID,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArticlesByPublicationRow
+	for rows.Next() {
+		var i ListArticlesByPublicationRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Summary,
+			&i.ThumbnailUrl,
+			&i.Status,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.Body,
+			&i.AuthorUsername,
+			&i.AuthorName,
+			&i.AuthorAvatarUrl,
+			&i.TotalClaps,
+			&i.PublicationName,
+			&i.PublicationSlug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublicationMembers = `-- name: ListPublicationMembers :many
+SELECT u.id, u.username, u.name, u.avatar_url, pm.role, pm.created_at
+FROM publication_members pm
+JOIN users u ON pm.user_id = u.id
+WHERE pm.publication_id = $1
+ORDER BY pm.created_at ASC
+`
+
+type ListPublicationMembersRow struct {
+	ID        uuid.UUID
+	Username  sql.NullString
+	Name      string
+	AvatarUrl string
+	Role      string
+	CreatedAt time.Time
+}
+
+func (q *Queries) ListPublicationMembers(ctx context.Context, publicationID uuid.UUID) ([]ListPublicationMembersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPublicationMembers, publicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPublicationMembersRow
+	for rows.Next() {
+		var i ListPublicationMembersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Name,
+			&i.AvatarUrl,
+			&i.Role,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublicationSubmissions = `-- name: ListPublicationSubmissions :many
+SELECT s.id, s.publication_id, s.article_id, s.submitted_by, s.status, s.note, s.reviewer_id, s.review_note, s.created_at, s.updated_at,
+    a.title AS article_title,
+    u.username AS submitter_username,
+    u.name AS submitter_name
+FROM publication_submissions s
+JOIN articles a ON s.article_id = a.id
+JOIN users u ON s.submitted_by = u.id
+WHERE s.publication_id = $1
+    AND ($2::text = '' OR s.status = $2::text)
+    AND ($3::uuid IS NULL OR s.submitted_by = $3::uuid)
+    AND ($4::timestamp IS NULL
+        OR (s.updated_at, s.id) < ($4::timestamp, $5::uuid))
+ORDER BY s.updated_at DESC, s.id DESC
+LIMIT $7 OFFSET $6
+`
+
+type ListPublicationSubmissionsParams struct {
+	PublicationID uuid.UUID
+	Status        string
+	SubmittedBy   uuid.NullUUID
+	CursorTime    sql.NullTime
+	CursorID      uuid.NullUUID
+	Offset        int32
+	Limit         int32
+}
+
+type ListPublicationSubmissionsRow struct {
+	ID                uuid.UUID
+	PublicationID     uuid.UUID
+	ArticleID         uuid.UUID
+	SubmittedBy       uuid.UUID
+	Status            string
+	Note              string
+	ReviewerID        uuid.NullUUID
+	ReviewNote        string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	ArticleTitle      string
+	SubmitterUsername sql.NullString
+	SubmitterName     string
+}
+
+func (q *Queries) ListPublicationSubmissions(ctx context.Context, arg ListPublicationSubmissionsParams) ([]ListPublicationSubmissionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPublicationSubmissions,
+		arg.PublicationID,
+		arg.Status,
+		arg.SubmittedBy,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPublicationSubmissionsRow
+	for rows.Next() {
+		var i ListPublicationSubmissionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PublicationID,
+			&i.ArticleID,
+			&i.SubmittedBy,
+			&i.Status,
+			&i.Note,
+			&i.ReviewerID,
+			&i.ReviewNote,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ArticleTitle,
+			&i.SubmitterUsername,
+			&i.SubmitterName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removePublicationMember = `-- name: RemovePublicationMember :exec
+DELETE FROM publication_members
+WHERE publication_id = $1 AND user_id = $2
+`
+
+type RemovePublicationMemberParams struct {
+	PublicationID uuid.UUID
+	UserID        uuid.UUID
+}
+
+func (q *Queries) RemovePublicationMember(ctx context.Context, arg RemovePublicationMemberParams) error {
+	_, err := q.db.ExecContext(ctx, removePublicationMember, arg.PublicationID, arg.UserID)
+	return err
+}
+
+const unfollowPublication = `-- name: UnfollowPublication :exec
+DELETE FROM publication_follows
+WHERE follower_id = $1 AND publication_id = $2
+`
+
+type UnfollowPublicationParams struct {
+	FollowerID    uuid.UUID
+	PublicationID uuid.UUID
+}
+
+func (q *Queries) UnfollowPublication(ctx context.Context, arg UnfollowPublicationParams) error {
+	_, err := q.db.ExecContext(ctx, unfollowPublication, arg.FollowerID, arg.PublicationID)
+	return err
+}
+
+const updatePublication = `-- name: UpdatePublication :one
+UPDATE publications
+SET name = $2, description = $3, avatar_url = $4, updated_at = NOW()
+WHERE id = $1
+RETURNING id, slug, name, description, avatar_url, created_at, updated_at
+`
+
+type UpdatePublicationParams struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	AvatarUrl   string
+}
+
+func (q *Queries) UpdatePublication(ctx context.Context, arg UpdatePublicationParams) (Publication, error) {
+	row := q.db.QueryRowContext(ctx, updatePublication,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.AvatarUrl,
+	)
+	var i Publication
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.Description,
+		&i.AvatarUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateSubmissionStatus = `-- name: UpdateSubmissionStatus :one
+UPDATE publication_submissions
+SET status = $1, reviewer_id = $2, review_note = $3,
+    updated_at = NOW()
+WHERE id = $4 AND status = $5
+RETURNING id, publication_id, article_id, submitted_by, status, note, reviewer_id, review_note, created_at, updated_at
+`
+
+type UpdateSubmissionStatusParams struct {
+	Status         string
+	ReviewerID     uuid.NullUUID
+	ReviewNote     string
+	ID             uuid.UUID
+	ExpectedStatus string
+}
+
+// Moves a submission on from expected_status; nothing is returned when another
+// review got there first.
+func (q *Queries) UpdateSubmissionStatus(ctx context.Context, arg UpdateSubmissionStatusParams) (PublicationSubmission, error) {
+	row := q.db.QueryRowContext(ctx, updateSubmissionStatus,
+		arg.Status,
+		arg.ReviewerID,
+		arg.ReviewNote,
+		arg.ID,
+		arg.ExpectedStatus,
+	)
+	var i PublicationSubmission
+	err := row.Scan(
+		&i.ID,
+		&i.PublicationID,
+		&i.ArticleID,
+		&i.SubmittedBy,
+		&i.Status,
+		&i.Note,
+		&i.ReviewerID,
+		&i.ReviewNote,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertSubmission = `-- name: UpsertSubmission :one
+INSERT INTO publication_submissions (publication_id, article_id, submitted_by, note)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (publication_id, article_id) DO UPDATE
+SET status = 'pending', note = EXCLUDED.note, submitted_by = EXCLUDED.submitted_by, updated_at = NOW()
+WHERE publication_submissions.status IN ('pending', 'changes_requested')
+RETURNING id, publication_id, article_id, submitted_by, status, note, reviewer_id, review_note, created_at, updated_at
+`
+
+type UpsertSubmissionParams struct {
+	PublicationID uuid.UUID
+	ArticleID     uuid.UUID
+	SubmittedBy   uuid.UUID
+	Note          string
+}
+
+func (q *Queries) UpsertSubmission(ctx context.Context, arg UpsertSubmissionParams) (PublicationSubmission, error) {
+	row := q.db.QueryRowContext(ctx, upsertSubmission,
+		arg.PublicationID,
+		arg.ArticleID,
+		arg.SubmittedBy,
+		arg.Note,
+	)
+	var i PublicationSubmission
+	err := row.Scan(
+		&i.ID,
+		&i.PublicationID,
+		&i.ArticleID,
+		&i.SubmittedBy,
+		&i.Status,
+		&i.Note,
+		&i.ReviewerID,
+		&i.ReviewNote,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,205 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: rankings.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const deleteStaleArticleScores = `-- name: DeleteStaleArticleScores :exec
+DELETE FROM article_scores
+WHERE computed_at < COALESCE((
+    SELECT MAX(s.computed_at) FROM article_scores s
+    WHERE s.computed_at < $1::timestamp
+), $1::timestamp)
+`
+
+// Removes generations older than the one before computed_at, which is kept
+// for readers still paging through it
+func (q *Queries) DeleteStaleArticleScores(ctx context.Context, computedAt time.Time) error {
+	_, err := q.db.ExecContext(ctx, deleteStaleArticleScores, computedAt)
+	return err
+}
+
+const listRankedArticles = `-- name: ListRankedArticles :many
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN $1::bool THEN a.body ELSE '' END)::text AS body,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug,
+    (CASE WHEN $2::text = 'top' THEN s.top ELSE s.trending END)::float8 AS score,
+    s.computed_at AS scores_computed_at
+FROM article_scores s
+JOIN articles a ON a.id = s.article_id
+JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE s.time_window = $3 AND a.status = 'published'
+    AND s.computed_at = COALESCE(
+        (SELECT g.computed_at FROM article_scores g WHERE g.computed_at = $4::timestamp LIMIT 1),
+        (SELECT MAX(g.computed_at) FROM article_scores g)
+    )
+    AND NOT article_muted($5::uuid, a.id, a.user_id, a.title, a.summary)
+    AND ($6::text IS NULL OR EXISTS (
+        SELECT 1 FROM article_tags at
+        JOIN tags t ON at.tag_id = t.id
+        WHERE at.article_id = a.id AND t.name = LOWER($6)
+    ))
+    AND ($7::float8 IS NULL
+        OR ((CASE WHEN $2::text = 'top' THEN s.top ELSE s.trending END), a.id)
+            < ($7::float8, $8::uuid))
+ORDER BY score DESC, a.id DESC
+LIMIT $10 OFFSET $9
+`
+
+type ListRankedArticlesParams struct {
+	IncludeBody      bool
+	Sort             string
+	TimeWindow       string
+	CursorComputedAt sql.NullTime
+	ViewerID         uuid.NullUUID
+	TagName          sql.NullString
+	CursorRank       sql.NullFloat64
+	CursorID         uuid.NullUUID
+	Offset           int32
+	Limit            int32
+}
+
+type ListRankedArticlesRow struct {
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	CommentCount        int32
+	Visibility          string
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	Body                string
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	TotalClaps          int32
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
+	Score               float64
+	ScoresComputedAt    time.Time
+}
+
+// Published articles ordered by a precomputed score, optionally within a tag.
+// Pages after the first read the generation of scores named by the cursor,
+// or the latest one once it has been deleted.
+func (q *Queries) ListRankedArticles(ctx context.Context, arg ListRankedArticlesParams) ([]ListRankedArticlesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRankedArticles,
+		arg.IncludeBody,
+		arg.Sort,
+		arg.TimeWindow,
+		arg.CursorComputedAt,
+		arg.ViewerID,
+		arg.TagName,
+		arg.CursorRank,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRankedArticlesRow
+	for rows.Next() {
+		var i ListRankedArticlesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Summary,
+			&i.ThumbnailUrl,
+			&i.Status,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.Body,
+			&i.AuthorUsername,
+			&i.AuthorName,
+			&i.AuthorAvatarUrl,
+			&i.TotalClaps,
+			&i.PublicationName,
+			&i.PublicationSlug,
+			&i.Score,
+			&i.ScoresComputedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const refreshArticleScores = `-- name: RefreshArticleScores :exec
+WITH windows AS (
+    SELECT 'day'::text AS name, INTERVAL '1 day' AS span
+    UNION ALL SELECT 'week', INTERVAL '7 days'
+    UNION ALL SELECT 'month', INTERVAL '30 days'
+), points AS (
+    SELECT a.id, w.name AS time_window,
+        EXTRACT(EPOCH FROM (NOW() - a.published_at)) / 3600 AS age_hours,
+        COALESCE((
+            SELECT SUM(s.claps + 3 * s.clappers + 4 * s.comments + 0.5 * s.reads + 0.1 * s.views)
+            FROM article_daily_stats s
+            WHERE s.article_id = a.id AND s.day >= (NOW() - w.span)::date
+        ), 0) AS points
+    FROM articles a
+    CROSS JOIN windows w
+    WHERE a.status = 'published' AND a.published_at >= NOW() - w.span
+)
+INSERT INTO article_scores (article_id, time_window, trending, top, computed_at)
+SELECT p.id, p.time_window,
+    p.points / POWER(GREATEST(p.age_hours, 0) + 2, 1.8),
+    p.points,
+    $1::timestamp
+FROM points p
+`
+
+// Scores every article published within each window as a new generation
+// stamped with computed_at. Engagement points come from the window's daily
+// rollups and weigh new clappers and comments above raw claps, plus reads and
+// views. top is the points; trending divides them by the article's age in
+// hours raised to a gravity of 1.8, so fresh articles rise and fade.
+func (q *Queries) RefreshArticleScores(ctx context.Context, computedAt time.Time) error {
+	_, err := q.db.ExecContext(ctx, refreshArticleScores, computedAt)
+	return err
+}
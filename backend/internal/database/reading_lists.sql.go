@@ -0,0 +1,489 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: reading_lists.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const addReadingListItem = `-- name: AddReadingListItem :execrows
+INSERT INTO reading_list_items (list_id, article_id, position)
+SELECT $1, $2, COALESCE(MAX(i.position), 0) + 1
+FROM reading_list_items i
+WHERE i.list_id = $1
+ON CONFLICT (list_id, article_id) DO NOTHING
+`
+
+type AddReadingListItemParams struct {
+	ListID    uuid.UUID
+	ArticleID uuid.UUID
+}
+
+// Appends an article to the end of a list; adding it again does nothing
+func (q *Queries) AddReadingListItem(ctx context.Context, arg AddReadingListItemParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, addReadingListItem, arg.ListID, arg.ArticleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createReadingList = `-- name: CreateReadingList :one
+INSERT INTO reading_lists (user_id, name, description, is_public)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, name, description, is_public, is_default, created_at, updated_at
+`
+
+type CreateReadingListParams struct {
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	IsPublic    bool
+}
+
+func (q *Queries) CreateReadingList(ctx context.Context, arg CreateReadingListParams) (ReadingList, error) {
+	row := q.db.QueryRowContext(ctx, createReadingList,
+		arg.UserID,
+		arg.Name,
+		arg.Description,
+		arg.IsPublic,
+	)
+	var i ReadingList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Description,
+		&i.IsPublic,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteReadingList = `-- name: DeleteReadingList :execrows
+DELETE FROM reading_lists
+WHERE id = $1 AND user_id = $2 AND NOT is_default
+`
+
+type DeleteReadingListParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+// The default list cannot be deleted
+func (q *Queries) DeleteReadingList(ctx context.Context, arg DeleteReadingListParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteReadingList, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getDefaultReadingList = `-- name: GetDefaultReadingList :one
+WITH created AS (
+    INSERT INTO reading_lists (user_id, name, is_default)
+    VALUES ($1, 'Reading list', true)
+    ON CONFLICT (user_id) WHERE is_default DO NOTHING
+    RETURNING id, user_id, name, description, is_public, is_default, created_at, updated_at
+)
+SELECT c.id, c.user_id, c.name, c.description, c.is_public, c.is_default, c.created_at, c.updated_at FROM created c
+UNION ALL
+SELECT l.id, l.user_id, l.name, l.description, l.is_public, l.is_default, l.created_at, l.updated_at FROM reading_lists l
+WHERE l.user_id = $1 AND l.is_default
+LIMIT 1
+`
+
+type GetDefaultReadingListRow struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	IsPublic    bool
+	IsDefault   bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Returns the user's default list, creating it on first use
+func (q *Queries) GetDefaultReadingList(ctx context.Context, userID uuid.UUID) (GetDefaultReadingListRow, error) {
+	row := q.db.QueryRowContext(ctx, getDefaultReadingList, userID)
+	var i GetDefaultReadingListRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Description,
+		&i.IsPublic,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getReadingList = `-- name: GetReadingList :one
+SELECT l.id, l.user_id, l.name, l.description, l.is_public, l.is_default, l.created_at, l.updated_at,
+    u.username AS owner_username,
+    (SELECT COUNT(*) FROM reading_list_items i WHERE i.list_id = l.id)::int AS item_count
+FROM reading_lists l
+JOIN users u ON l.user_id = u.id
+WHERE l.id = $1
+`
+
+type GetReadingListRow struct {
+	ReadingList   ReadingList
+	OwnerUsername sql.NullString
+	ItemCount     int32
+}
+
+func (q *Queries) GetReadingList(ctx context.Context, id uuid.UUID) (GetReadingListRow, error) {
+	row := q.db.QueryRowContext(ctx, getReadingList, id)
+	var i GetReadingListRow
+	err := row.Scan(
+		&i.ReadingList.ID,
+		&i.ReadingList.UserID,
+		&i.ReadingList.Name,
+		&i.ReadingList.Description,
+		&i.ReadingList.IsPublic,
+		&i.ReadingList.IsDefault,
+		&i.ReadingList.CreatedAt,
+		&i.ReadingList.UpdatedAt,
+		&i.OwnerUsername,
+		&i.ItemCount,
+	)
+	return i, err
+}
+
+const listBookmarkedArticleIDs = `-- name: ListBookmarkedArticleIDs :many
+SELECT DISTINCT i.article_id
+FROM reading_list_items i
+JOIN reading_lists l ON i.list_id = l.id
+WHERE l.user_id = $1 AND i.article_id = ANY($2::uuid[])
+`
+
+type ListBookmarkedArticleIDsParams struct {
+	UserID     uuid.UUID
+	ArticleIds []uuid.UUID
+}
+
+// Which of the given articles are in any of the user's lists
+func (q *Queries) ListBookmarkedArticleIDs(ctx context.Context, arg ListBookmarkedArticleIDsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listBookmarkedArticleIDs, arg.UserID, pq.Array(arg.ArticleIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var article_id uuid.UUID
+		if err := rows.Scan(&article_id); err != nil {
+			return nil, err
+		}
+		items = append(items, article_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublicReadingLists = `-- name: ListPublicReadingLists :many
+SELECT l.id, l.user_id, l.name, l.description, l.is_public, l.is_default, l.created_at, l.updated_at,
+    (SELECT COUNT(*) FROM reading_list_items i WHERE i.list_id = l.id)::int AS item_count
+FROM reading_lists l
+JOIN users u ON l.user_id = u.id
+WHERE u.username = $1 AND l.is_public
+ORDER BY l.is_default DESC, l.created_at ASC
+`
+
+type ListPublicReadingListsRow struct {
+	ReadingList ReadingList
+	ItemCount   int32
+}
+
+func (q *Queries) ListPublicReadingLists(ctx context.Context, username sql.NullString) ([]ListPublicReadingListsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPublicReadingLists, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPublicReadingListsRow
+	for rows.Next() {
+		var i ListPublicReadingListsRow
+		if err := rows.Scan(
+			&i.ReadingList.ID,
+			&i.ReadingList.UserID,
+			&i.ReadingList.Name,
+			&i.ReadingList.Description,
+			&i.ReadingList.IsPublic,
+			&i.ReadingList.IsDefault,
+			&i.ReadingList.CreatedAt,
+			&i.ReadingList.UpdatedAt,
+			&i.ItemCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReadingListArticles = `-- name: ListReadingListArticles :many
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN $1::bool THEN a.body ELSE '' END)::text AS body,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug,
+    i.position,
+    i.added_at
+FROM reading_list_items i
+JOIN articles a ON i.article_id = a.id
+JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE i.list_id = $2
+    AND ($3::uuid IS NULL
+        OR (i.position, a.id) > ($4::int, $3::uuid))
+ORDER BY i.position ASC, a.id ASC
+LIMIT $6 OFFSET $5
+`
+
+type ListReadingListArticlesParams struct {
+	IncludeBody    bool
+	ListID         uuid.UUID
+	CursorID       uuid.NullUUID
+	CursorPosition sql.NullInt32
+	Offset         int32
+	Limit          int32
+}
+
+type ListReadingListArticlesRow struct {
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	CommentCount        int32
+	Visibility          string
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	Body                string
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	TotalClaps          int32
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
+	Position            int32
+	AddedAt             time.Time
+}
+
+// Articles in a list in order. Only published articles can be added.
+func (q *Queries) ListReadingListArticles(ctx context.Context, arg ListReadingListArticlesParams) ([]ListReadingListArticlesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listReadingListArticles,
+		arg.IncludeBody,
+		arg.ListID,
+		arg.CursorID,
+		arg.CursorPosition,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListReadingListArticlesRow
+	for rows.Next() {
+		var i ListReadingListArticlesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Summary,
+			&i.ThumbnailUrl,
+			&i.Status,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.Body,
+			&i.AuthorUsername,
+			&i.AuthorName,
+			&i.AuthorAvatarUrl,
+			&i.TotalClaps,
+			&i.PublicationName,
+			&i.PublicationSlug,
+			&i.Position,
+			&i.AddedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReadingLists = `-- name: ListReadingLists :many
+SELECT l.id, l.user_id, l.name, l.description, l.is_public, l.is_default, l.created_at, l.updated_at,
+    (SELECT COUNT(*) FROM reading_list_items i WHERE i.list_id = l.id)::int AS item_count
+FROM reading_lists l
+WHERE l.user_id = $1
+ORDER BY l.is_default DESC, l.created_at ASC
+`
+
+type ListReadingListsRow struct {
+	ReadingList ReadingList
+	ItemCount   int32
+}
+
+func (q *Queries) ListReadingLists(ctx context.Context, userID uuid.UUID) ([]ListReadingListsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listReadingLists, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListReadingListsRow
+	for rows.Next() {
+		var i ListReadingListsRow
+		if err := rows.Scan(
+			&i.ReadingList.ID,
+			&i.ReadingList.UserID,
+			&i.ReadingList.Name,
+			&i.ReadingList.Description,
+			&i.ReadingList.IsPublic,
+			&i.ReadingList.IsDefault,
+			&i.ReadingList.CreatedAt,
+			&i.ReadingList.UpdatedAt,
+			&i.ItemCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeReadingListItem = `-- name: RemoveReadingListItem :execrows
+DELETE FROM reading_list_items
+WHERE list_id = $1 AND article_id = $2
+`
+
+type RemoveReadingListItemParams struct {
+	ListID    uuid.UUID
+	ArticleID uuid.UUID
+}
+
+func (q *Queries) RemoveReadingListItem(ctx context.Context, arg RemoveReadingListItemParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeReadingListItem, arg.ListID, arg.ArticleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const reorderReadingListItems = `-- name: ReorderReadingListItems :execrows
+UPDATE reading_list_items
+SET position = array_position($1::uuid[], article_id)
+WHERE list_id = $2 AND article_id = ANY($1::uuid[])
+`
+
+type ReorderReadingListItemsParams struct {
+	ArticleIds []uuid.UUID
+	ListID     uuid.UUID
+}
+
+// Positions the listed articles in the order given
+func (q *Queries) ReorderReadingListItems(ctx context.Context, arg ReorderReadingListItemsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reorderReadingListItems, pq.Array(arg.ArticleIds), arg.ListID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateReadingList = `-- name: UpdateReadingList :one
+UPDATE reading_lists
+SET name = $3, description = $4, is_public = $5, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, name, description, is_public, is_default, created_at, updated_at
+`
+
+type UpdateReadingListParams struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	IsPublic    bool
+}
+
+func (q *Queries) UpdateReadingList(ctx context.Context, arg UpdateReadingListParams) (ReadingList, error) {
+	row := q.db.QueryRowContext(ctx, updateReadingList,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.Description,
+		arg.IsPublic,
+	)
+	var i ReadingList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Description,
+		&i.IsPublic,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
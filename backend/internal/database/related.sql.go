@@ -0,0 +1,233 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: related.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const computeRelatedArticles = `-- name: ComputeRelatedArticles :many
+WITH source AS (
+    SELECT s.id, s.user_id, s.search_vector FROM articles s
+    WHERE s.id = $2
+), terms AS (
+    SELECT COALESCE(string_agg(quote_literal(l.lexeme), ' | '), '')::tsquery AS query
+    FROM (
+        SELECT u.lexeme FROM source src, unnest(src.search_vector) AS u(lexeme, positions, weights)
+        ORDER BY COALESCE(array_length(u.positions, 1), 0) DESC, u.lexeme
+        LIMIT 20
+    ) l
+), scored AS (
+    SELECT a.id,
+        (SELECT COUNT(*) FROM article_tags at
+            WHERE at.article_id = a.id
+                AND at.tag_id IN (SELECT st.tag_id FROM article_tags st WHERE st.article_id = $2)
+        ) AS shared_tags,
+        (a.user_id = src.user_id) AS same_author,
+        ts_rank(a.search_vector, terms.query) AS similarity
+    FROM articles a, source src, terms
+    WHERE a.status = 'published' AND a.id <> src.id
+)
+SELECT sc.id FROM scored sc
+WHERE sc.shared_tags > 0 OR sc.same_author OR sc.similarity > 0
+ORDER BY (3 * sc.shared_tags + (CASE WHEN sc.same_author THEN 2 ELSE 0 END) + 10 * sc.similarity) DESC, sc.id
+LIMIT $1
+`
+
+type ComputeRelatedArticlesParams struct {
+	Limit     int32
+	ArticleID uuid.UUID
+}
+
+// Ranks published articles by shared tags, same author and text similarity
+// to an article. Similarity matches the article's 20 most frequent lexemes
+// against each candidate's search_vector.
+func (q *Queries) ComputeRelatedArticles(ctx context.Context, arg ComputeRelatedArticlesParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, computeRelatedArticles, arg.Limit, arg.ArticleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRelatedArticleCache = `-- name: GetRelatedArticleCache :one
+SELECT related_ids FROM article_related_cache
+WHERE article_id = $1 AND computed_at > NOW() - INTERVAL '1 day'
+`
+
+func (q *Queries) GetRelatedArticleCache(ctx context.Context, articleID uuid.UUID) ([]uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, getRelatedArticleCache, articleID)
+	var related_ids []uuid.UUID
+	err := row.Scan(pq.Array(&related_ids))
+	return related_ids, err
+}
+
+const invalidateRelatedArticleCache = `-- name: InvalidateRelatedArticleCache :exec
+DELETE FROM article_related_cache c
+WHERE c.article_id = $1
+    OR c.article_id IN (
+        SELECT o.article_id FROM article_tags o
+        JOIN article_tags t ON t.tag_id = o.tag_id
+        WHERE t.article_id = $1
+    )
+    OR c.article_id IN (
+        SELECT a.id FROM articles a
+        WHERE a.user_id = (SELECT s.user_id FROM articles s WHERE s.id = $1)
+    )
+`
+
+// Drops the cached lists of an article, its author's articles and articles
+// sharing a tag with it: the ones a newly published article may belong in
+func (q *Queries) InvalidateRelatedArticleCache(ctx context.Context, articleID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, invalidateRelatedArticleCache, articleID)
+	return err
+}
+
+const listRelatedArticles = `-- name: ListRelatedArticles :many
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
+FROM articles a
+JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
+LEFT JOIN article_reads r ON r.article_id = a.id
+    AND r.user_id = $1::uuid AND r.finished_at IS NOT NULL
+WHERE a.id = ANY($2::uuid[]) AND a.status = 'published'
+    AND ($3::text <> 'hide' OR r.article_id IS NULL)
+ORDER BY (CASE WHEN $3::text = 'last' AND r.article_id IS NOT NULL THEN 1 ELSE 0 END),
+    array_position($2::uuid[], a.id)
+LIMIT $4
+`
+
+type ListRelatedArticlesParams struct {
+	ViewerID uuid.NullUUID
+	Ids      []uuid.UUID
+	Finished string
+	Limit    int32
+}
+
+type ListRelatedArticlesRow struct {
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	CommentCount        int32
+	Visibility          string
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	TotalClaps          int32
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
+}
+
+// Loads cached related articles in order. Articles the viewer has finished
+// are listed as usual, last or not at all when finished is show, last or hide.
+func (q *Queries) ListRelatedArticles(ctx context.Context, arg ListRelatedArticlesParams) ([]ListRelatedArticlesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRelatedArticles,
+		arg.ViewerID,
+		pq.Array(arg.Ids),
+		arg.Finished,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRelatedArticlesRow
+	for rows.Next() {
+		var i ListRelatedArticlesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Summary,
+			&i.ThumbnailUrl,
+			&i.Status,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.AuthorUsername,
+			&i.AuthorName,
+			&i.AuthorAvatarUrl,
+			&i.TotalClaps,
+			&i.PublicationName,
+			&i.PublicationSlug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setRelatedArticleCache = `-- name: SetRelatedArticleCache :exec
+INSERT INTO article_related_cache (article_id, related_ids)
+VALUES ($1, $2::uuid[])
+ON CONFLICT (article_id) DO UPDATE
+SET related_ids = EXCLUDED.related_ids, computed_at = NOW()
+`
+
+type SetRelatedArticleCacheParams struct {
+	ArticleID  uuid.UUID
+	RelatedIds []uuid.UUID
+}
+
+func (q *Queries) SetRelatedArticleCache(ctx context.Context, arg SetRelatedArticleCacheParams) error {
+	_, err := q.db.ExecContext(ctx, setRelatedArticleCache, arg.ArticleID, pq.Array(arg.RelatedIds))
+	return err
+}
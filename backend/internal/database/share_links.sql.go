@@ -0,0 +1,140 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: share_links.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createShareLink = `-- name: CreateShareLink :one
+INSERT INTO article_share_links (article_id, created_by, label, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, article_id, created_by, label, expires_at, revoked_at, created_at
+`
+
+type CreateShareLinkParams struct {
+	ArticleID uuid.UUID
+	CreatedBy uuid.UUID
+	Label     string
+	ExpiresAt sql.NullTime
+}
+
+func (q *Queries) CreateShareLink(ctx context.Context, arg CreateShareLinkParams) (ArticleShareLink, error) {
+	row := q.db.QueryRowContext(ctx, createShareLink,
+		arg.ArticleID,
+		arg.CreatedBy,
+		arg.Label,
+		arg.ExpiresAt,
+	)
+	var i ArticleShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.ArticleID,
+		&i.CreatedBy,
+		&i.Label,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getActiveShareLink = `-- name: GetActiveShareLink :one
+SELECT id, article_id, created_by, label, expires_at, revoked_at, created_at FROM article_share_links
+WHERE id = $1
+    AND revoked_at IS NULL
+    AND (expires_at IS NULL OR expires_at > NOW())
+`
+
+func (q *Queries) GetActiveShareLink(ctx context.Context, id uuid.UUID) (ArticleShareLink, error) {
+	row := q.db.QueryRowContext(ctx, getActiveShareLink, id)
+	var i ArticleShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.ArticleID,
+		&i.CreatedBy,
+		&i.Label,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listShareLinks = `-- name: ListShareLinks :many
+SELECT l.id, l.article_id, l.created_by, l.label, l.expires_at, l.revoked_at, l.created_at,
+    u.username AS creator_username
+FROM article_share_links l
+JOIN users u ON l.created_by = u.id
+WHERE l.article_id = $1
+ORDER BY l.created_at DESC
+`
+
+type ListShareLinksRow struct {
+	ID              uuid.UUID
+	ArticleID       uuid.UUID
+	CreatedBy       uuid.UUID
+	Label           string
+	ExpiresAt       sql.NullTime
+	RevokedAt       sql.NullTime
+	CreatedAt       time.Time
+	CreatorUsername sql.NullString
+}
+
+func (q *Queries) ListShareLinks(ctx context.Context, articleID uuid.UUID) ([]ListShareLinksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listShareLinks, articleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListShareLinksRow
+	for rows.Next() {
+		var i ListShareLinksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ArticleID,
+			&i.CreatedBy,
+			&i.Label,
+			&i.ExpiresAt,
+			&i.RevokedAt,
+			&i.CreatedAt,
+			&i.CreatorUsername,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeShareLink = `-- name: RevokeShareLink :execrows
+UPDATE article_share_links
+SET revoked_at = NOW()
+WHERE id = $1 AND article_id = $2 AND revoked_at IS NULL
+`
+
+type RevokeShareLinkParams struct {
+	ID        uuid.UUID
+	ArticleID uuid.UUID
+}
+
+func (q *Queries) RevokeShareLink(ctx context.Context, arg RevokeShareLinkParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeShareLink, arg.ID, arg.ArticleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const addArticleTag = `-- name: AddArticleTag :exec
@@ -85,47 +86,118 @@ func (q *Queries) GetTagByName(ctx context.Context, lower string) (Tag, error) {
 	return i, err
 }
 
+const getTagsForArticles = `-- name: GetTagsForArticles :many
+SELECT at.article_id, t.id, t.name, t.created_at
+FROM article_tags at
+JOIN tags t ON t.id = at.tag_id
+WHERE at.article_id = ANY($1::uuid[])
+ORDER BY at.article_id, t.name
+`
+
+type GetTagsForArticlesRow struct {
+	ArticleID uuid.UUID
+	Tag       Tag
+}
+
+func (q *Queries) GetTagsForArticles(ctx context.Context, articleIds []uuid.UUID) ([]GetTagsForArticlesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTagsForArticles, pq.Array(articleIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTagsForArticlesRow
+	for rows.Next() {
+		var i GetTagsForArticlesRow
+		if err := rows.Scan(
+			&i.ArticleID,
+			&i.Tag.ID,
+			&i.Tag.Name,
+			&i.Tag.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listArticlesByTag = `-- name: ListArticlesByTag :many
-SELECT a.id, a.user_id, a.title, a.body, a.summary, a.thumbnail_url, a.status, a.published_at, a.created_at, a.updated_at, a.search_vector,
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN $1::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
 FROM articles a
 JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
 JOIN article_tags at ON a.id = at.article_id
 JOIN tags t ON at.tag_id = t.id
-WHERE t.name = LOWER($1) AND a.status = 'published'
-ORDER BY a.published_at DESC
-LIMIT $2 OFFSET $3
+WHERE t.name = LOWER($2) AND a.status = 'published'
+    AND NOT article_muted($3::uuid, a.id, a.user_id, a.title, a.summary)
+    AND ($4::timestamp IS NULL
+        OR (a.published_at, a.id) < ($4::timestamp, $5::uuid))
+ORDER BY a.published_at DESC, a.id DESC
+LIMIT $7 OFFSET $6
 `
 
 type ListArticlesByTagParams struct {
-	Lower  string
-	Limit  int32
-	Offset int32
+	IncludeBody bool
+	TagName     string
+	ViewerID    uuid.NullUUID
+	CursorTime  sql.NullTime
+	CursorID    uuid.NullUUID
+	Offset      int32
+	Limit       int32
 }
 
 type ListArticlesByTagRow struct {
-	ID              uuid.UUID
-	UserID          uuid.UUID
-	Title           string
-	Body            string
-	Summary         string
-	ThumbnailUrl    string
-	Status          string
-	PublishedAt     sql.NullTime
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	SearchVector    interface{}
-	AuthorUsername  sql.NullString
-	AuthorName      string
-	AuthorAvatarUrl string
-	TotalClaps      int32
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	Title               string
+	Summary             string
+	ThumbnailUrl        string
+	Status              string
+	PublishedAt         sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	PublicationID       uuid.NullUUID
+	Revision            int32
+	ReadingTime         int32
+	CommentCount        int32
+	Visibility          string
+	CanonicalUrl        string
+	OriginalPublishedAt sql.NullTime
+	Language            string
+	Body                string
+	AuthorUsername      sql.NullString
+	AuthorName          string
+	AuthorAvatarUrl     string
+	TotalClaps          int32
+	PublicationName     sql.NullString
+	PublicationSlug     sql.NullString
 }
 
 func (q *Queries) ListArticlesByTag(ctx context.Context, arg ListArticlesByTagParams) ([]ListArticlesByTagRow, error) {
-	rows, err := q.db.QueryContext(ctx, listArticlesByTag, arg.Lower, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listArticlesByTag,
+		arg.IncludeBody,
+		arg.TagName,
+		arg.ViewerID,
+		arg.CursorTime,
+		arg.CursorID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -137,18 +209,27 @@ func (q *Queries) ListArticlesByTag(ctx context.Context, arg ListArticlesByTagPa
 			&i.ID,
 			&i.UserID,
 			&i.Title,
-			&i.Body,
 			&i.Summary,
 			&i.ThumbnailUrl,
 			&i.Status,
 			&i.PublishedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			&i.SearchVector,
+			&i.PublicationID,
+			&i.Revision,
+			&i.ReadingTime,
+			&i.CommentCount,
+			&i.Visibility,
+			&i.CanonicalUrl,
+			&i.OriginalPublishedAt,
+			&i.Language,
+			&i.Body,
 			&i.AuthorUsername,
 			&i.AuthorName,
 			&i.AuthorAvatarUrl,
 			&i.TotalClaps,
+			&i.PublicationName,
+			&i.PublicationSlug,
 		); err != nil {
 			return nil, err
 		}
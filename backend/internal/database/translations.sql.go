@@ -0,0 +1,120 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: translations.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const linkArticleTranslation = `-- name: LinkArticleTranslation :exec
+WITH target AS (
+    SELECT COALESCE(s.translation_group_id, o.translation_group_id, gen_random_uuid()) AS group_id
+    FROM articles s, articles o
+    WHERE s.id = $1 AND o.id = $2
+)
+UPDATE articles a
+SET translation_group_id = target.group_id
+FROM target
+WHERE a.id IN ($1, $2)
+    OR a.translation_group_id IN (
+        SELECT g.translation_group_id FROM articles g
+        WHERE g.id IN ($1, $2) AND g.translation_group_id IS NOT NULL
+    )
+`
+
+type LinkArticleTranslationParams struct {
+	ArticleID     uuid.UUID
+	TranslationID uuid.UUID
+}
+
+// Puts two articles, along with any translations either already has, in one
+// translation group
+func (q *Queries) LinkArticleTranslation(ctx context.Context, arg LinkArticleTranslationParams) error {
+	_, err := q.db.ExecContext(ctx, linkArticleTranslation, arg.ArticleID, arg.TranslationID)
+	return err
+}
+
+const listArticleTranslations = `-- name: ListArticleTranslations :many
+SELECT t.id, t.user_id, t.title, t.language, t.status, t.slug, t.published_at
+FROM articles a
+JOIN articles t ON t.translation_group_id = a.translation_group_id AND t.id <> a.id
+WHERE a.id = $1 AND ($2::bool OR t.status = 'published')
+ORDER BY t.language, t.id
+`
+
+type ListArticleTranslationsParams struct {
+	ID            uuid.UUID
+	IncludeDrafts bool
+}
+
+type ListArticleTranslationsRow struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Title       string
+	Language    string
+	Status      string
+	Slug        sql.NullString
+	PublishedAt sql.NullTime
+}
+
+// Other articles in an article's translation group. Drafts are only listed
+// when include_drafts is true.
+func (q *Queries) ListArticleTranslations(ctx context.Context, arg ListArticleTranslationsParams) ([]ListArticleTranslationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listArticleTranslations, arg.ID, arg.IncludeDrafts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArticleTranslationsRow
+	for rows.Next() {
+		var i ListArticleTranslationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Language,
+			&i.Status,
+			&i.Slug,
+			&i.PublishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unlinkArticleTranslation = `-- name: UnlinkArticleTranslation :execrows
+WITH source AS (
+    SELECT s.translation_group_id AS group_id FROM articles s
+    WHERE s.id = $1
+)
+UPDATE articles a
+SET translation_group_id = NULL
+FROM source
+WHERE a.translation_group_id = source.group_id
+    AND (a.id = $1
+        OR (SELECT COUNT(*) FROM articles c WHERE c.translation_group_id = source.group_id) <= 2)
+`
+
+// Removes an article from its translation group, dissolving the group when
+// a single article would be left in it
+func (q *Queries) UnlinkArticleTranslation(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unlinkArticleTranslation, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -23,7 +23,7 @@ VALUES (
     $3,
     $4
 )
-RETURNING id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url
+RETURNING id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url, revision, follower_count, following_count, is_admin, is_member, history_paused
 `
 
 type CreateUserParams struct {
@@ -51,6 +51,12 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Name,
 		&i.Bio,
 		&i.AvatarUrl,
+		&i.Revision,
+		&i.FollowerCount,
+		&i.FollowingCount,
+		&i.IsAdmin,
+		&i.IsMember,
+		&i.HistoryPaused,
 	)
 	return i, err
 }
@@ -65,7 +71,7 @@ func (q *Queries) DeleteUsers(ctx context.Context) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url FROM users
+SELECT id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url, revision, follower_count, following_count, is_admin, is_member, history_paused FROM users
 WHERE email = $1
 `
 
@@ -82,12 +88,18 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Name,
 		&i.Bio,
 		&i.AvatarUrl,
+		&i.Revision,
+		&i.FollowerCount,
+		&i.FollowingCount,
+		&i.IsAdmin,
+		&i.IsMember,
+		&i.HistoryPaused,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url FROM users
+SELECT id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url, revision, follower_count, following_count, is_admin, is_member, history_paused FROM users
 WHERE id = $1
 `
 
@@ -104,12 +116,18 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.Name,
 		&i.Bio,
 		&i.AvatarUrl,
+		&i.Revision,
+		&i.FollowerCount,
+		&i.FollowingCount,
+		&i.IsAdmin,
+		&i.IsMember,
+		&i.HistoryPaused,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url FROM users
+SELECT id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url, revision, follower_count, following_count, is_admin, is_member, history_paused FROM users
 WHERE username = $1
 `
 
@@ -126,30 +144,126 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username sql.NullString
 		&i.Name,
 		&i.Bio,
 		&i.AvatarUrl,
+		&i.Revision,
+		&i.FollowerCount,
+		&i.FollowingCount,
+		&i.IsAdmin,
+		&i.IsMember,
+		&i.HistoryPaused,
+	)
+	return i, err
+}
+
+const patchUserProfile = `-- name: PatchUserProfile :one
+UPDATE users
+SET name = COALESCE($1, name),
+    bio = COALESCE($2, bio),
+    avatar_url = COALESCE($3, avatar_url),
+    revision = revision + 1,
+    updated_at = NOW()
+WHERE id = $4
+    AND ($5::int = 0 OR revision = $5::int)
+RETURNING id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url, revision, follower_count, following_count, is_admin, is_member, history_paused
+`
+
+type PatchUserProfileParams struct {
+	Name             sql.NullString
+	Bio              sql.NullString
+	AvatarUrl        sql.NullString
+	ID               uuid.UUID
+	ExpectedRevision int32
+}
+
+// Only non-null arguments are written; the rest keep their current values.
+func (q *Queries) PatchUserProfile(ctx context.Context, arg PatchUserProfileParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, patchUserProfile,
+		arg.Name,
+		arg.Bio,
+		arg.AvatarUrl,
+		arg.ID,
+		arg.ExpectedRevision,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Email,
+		&i.HashedPassword,
+		&i.Username,
+		&i.Name,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.Revision,
+		&i.FollowerCount,
+		&i.FollowingCount,
+		&i.IsAdmin,
+		&i.IsMember,
+		&i.HistoryPaused,
+	)
+	return i, err
+}
+
+const setUserMembership = `-- name: SetUserMembership :one
+UPDATE users
+SET is_member = $1, updated_at = NOW()
+WHERE id = $2
+RETURNING id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url, revision, follower_count, following_count, is_admin, is_member, history_paused
+`
+
+type SetUserMembershipParams struct {
+	IsMember bool
+	ID       uuid.UUID
+}
+
+func (q *Queries) SetUserMembership(ctx context.Context, arg SetUserMembershipParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, setUserMembership, arg.IsMember, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Email,
+		&i.HashedPassword,
+		&i.Username,
+		&i.Name,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.Revision,
+		&i.FollowerCount,
+		&i.FollowingCount,
+		&i.IsAdmin,
+		&i.IsMember,
+		&i.HistoryPaused,
 	)
 	return i, err
 }
 
 const updateUserProfile = `-- name: UpdateUserProfile :one
 UPDATE users
-SET name = $2, bio = $3, avatar_url = $4, updated_at = NOW()
-WHERE id = $1
-RETURNING id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url
+SET name = $1, bio = $2, avatar_url = $3,
+    revision = revision + 1, updated_at = NOW()
+WHERE id = $4
+    AND ($5::int = 0 OR revision = $5::int)
+RETURNING id, created_at, updated_at, email, hashed_password, username, name, bio, avatar_url, revision, follower_count, following_count, is_admin, is_member, history_paused
 `
 
 type UpdateUserProfileParams struct {
-	ID        uuid.UUID
-	Name      string
-	Bio       string
-	AvatarUrl string
+	Name             string
+	Bio              string
+	AvatarUrl        string
+	ID               uuid.UUID
+	ExpectedRevision int32
 }
 
+// A zero expected_revision skips the optimistic concurrency check.
 func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error) {
 	row := q.db.QueryRowContext(ctx, updateUserProfile,
-		arg.ID,
 		arg.Name,
 		arg.Bio,
 		arg.AvatarUrl,
+		arg.ID,
+		arg.ExpectedRevision,
 	)
 	var i User
 	err := row.Scan(
@@ -162,6 +276,12 @@ func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfilePa
 		&i.Name,
 		&i.Bio,
 		&i.AvatarUrl,
+		&i.Revision,
+		&i.FollowerCount,
+		&i.FollowingCount,
+		&i.IsAdmin,
+		&i.IsMember,
+		&i.HistoryPaused,
 	)
 	return i, err
 }
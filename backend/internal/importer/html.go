@@ -0,0 +1,183 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	blankLines = regexp.MustCompile(`\n{3,}`)
+	spaceLines = regexp.MustCompile(`(?m)^[ \t]+$`)
+	whitespace = regexp.MustCompile(`\s+`)
+)
+
+// HTMLToMarkdown converts post HTML to a Markdown article body. Formatting
+// Markdown cannot express is dropped and only the text is kept.
+func HTMLToMarkdown(src string) (string, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	var sb strings.Builder
+	for _, n := range nodes {
+		writeMarkdown(&sb, n, "")
+	}
+	out := spaceLines.ReplaceAllString(sb.String(), "")
+	out = blankLines.ReplaceAllString(out, "\n\n")
+	return strings.TrimSpace(out) + "\n", nil
+}
+
+// writeMarkdown renders a node and its children. prefix is prepended to every
+// line inside block quotes and list items.
+func writeMarkdown(sb *strings.Builder, n *html.Node, prefix string) {
+	switch n.Type {
+	case html.TextNode:
+		sb.WriteString(whitespace.ReplaceAllString(n.Data, " "))
+		return
+	case html.ElementNode:
+	default:
+		writeChildren(sb, n, prefix)
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Head:
+		return
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		sb.WriteString("\n\n" + prefix + strings.Repeat("#", level) + " ")
+		sb.WriteString(strings.TrimSpace(inlineText(n)))
+		sb.WriteString("\n\n")
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Figure:
+		var block strings.Builder
+		writeChildren(&block, n, prefix)
+		sb.WriteString("\n\n" + prefix + strings.TrimSpace(block.String()) + "\n\n")
+	case atom.Figcaption:
+		sb.WriteString("\n\n" + prefix + "*")
+		sb.WriteString(strings.TrimSpace(inlineText(n)))
+		sb.WriteString("*\n\n")
+	case atom.Br:
+		sb.WriteString("  \n" + prefix)
+	case atom.Hr:
+		sb.WriteString("\n\n" + prefix + "---\n\n")
+	case atom.Strong, atom.B:
+		writeWrapped(sb, n, prefix, "**")
+	case atom.Em, atom.I:
+		writeWrapped(sb, n, prefix, "*")
+	case atom.Code:
+		sb.WriteString("`" + textContent(n) + "`")
+	case atom.Pre:
+		sb.WriteString("\n\n" + prefix + "```\n")
+		for _, line := range strings.Split(strings.TrimRight(textContent(n), "\n"), "\n") {
+			sb.WriteString(prefix + line + "\n")
+		}
+		sb.WriteString(prefix + "```\n\n")
+	case atom.A:
+		href := attr(n, "href")
+		text := strings.TrimSpace(inlineText(n))
+		if href == "" || text == "" {
+			sb.WriteString(text)
+			return
+		}
+		sb.WriteString("[" + text + "](" + href + ")")
+	case atom.Img:
+		src := attr(n, "src")
+		if src != "" {
+			sb.WriteString("![" + attr(n, "alt") + "](" + src + ")")
+		}
+	case atom.Blockquote:
+		// Render the quote on its own, then mark every line of it, blank
+		// lines included, so multi-paragraph quotes stay one quote
+		var block strings.Builder
+		writeChildren(&block, n, "")
+		text := spaceLines.ReplaceAllString(block.String(), "")
+		text = strings.TrimSpace(blankLines.ReplaceAllString(text, "\n\n"))
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			if line == "" {
+				lines[i] = prefix + ">"
+			} else {
+				lines[i] = prefix + "> " + line
+			}
+		}
+		sb.WriteString("\n\n" + strings.Join(lines, "\n") + "\n\n")
+	case atom.Ul, atom.Ol:
+		sb.WriteString("\n\n")
+		i := 1
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode || c.DataAtom != atom.Li {
+				continue
+			}
+			marker := "- "
+			if n.DataAtom == atom.Ol {
+				marker = fmt.Sprintf("%d. ", i)
+				i++
+			}
+			sb.WriteString(prefix + marker)
+			var item strings.Builder
+			writeChildren(&item, c, prefix+strings.Repeat(" ", len(marker)))
+			sb.WriteString(strings.TrimSpace(blankLines.ReplaceAllString(item.String(), "\n\n")))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	default:
+		writeChildren(sb, n, prefix)
+	}
+}
+
+func writeChildren(sb *strings.Builder, n *html.Node, prefix string) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeMarkdown(sb, c, prefix)
+	}
+}
+
+func writeWrapped(sb *strings.Builder, n *html.Node, prefix, marker string) {
+	text := strings.TrimSpace(inlineText(n))
+	if text == "" {
+		return
+	}
+	sb.WriteString(marker + text + marker)
+}
+
+// inlineText renders a node's children as inline Markdown
+func inlineText(n *html.Node) string {
+	var sb strings.Builder
+	writeChildren(&sb, n, "")
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// textContent returns a node's raw text, keeping whitespace
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(textContent(c))
+	}
+	return sb.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// hasClass reports whether an element's class attribute contains class
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(attr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+// Package importer reads posts from other blogging platforms' export formats
+// and converts them to this platform's Markdown article bodies.
+package importer
+
+import "time"
+
+// Post is a single post read from an export file
+type Post struct {
+	// Key identifies the post within its source so a repeated import can skip it
+	Key         string
+	Title       string
+	Summary     string
+	Body        string
+	Tags        []string
+	Status      string
+	PublishedAt *time.Time
+	// CanonicalURL is the post's address on the source platform
+	CanonicalURL string
+	// Skip explains why the post should not be imported, if it shouldn't
+	Skip string
+}
@@ -0,0 +1,128 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// ParseMediumExport reads the posts from a Medium export archive. Each post is
+// an HTML file under posts/; unpublished drafts are named draft_*.html.
+func ParseMediumExport(data []byte) ([]Post, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Medium export archive: %w", err)
+	}
+
+	var posts []Post
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || path.Ext(f.Name) != ".html" || path.Base(path.Dir(f.Name)) != "posts" {
+			continue
+		}
+
+		post := Post{Key: "medium:" + path.Base(f.Name)}
+		rc, err := f.Open()
+		if err != nil {
+			post.Skip = "could not be read"
+			posts = append(posts, post)
+			continue
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			post.Skip = "could not be read"
+			posts = append(posts, post)
+			continue
+		}
+
+		if err := parseMediumPost(content, &post); err != nil {
+			post.Skip = err.Error()
+		}
+		if strings.HasPrefix(path.Base(f.Name), "draft_") {
+			post.Status = "draft"
+			post.PublishedAt = nil
+		}
+		posts = append(posts, post)
+	}
+	return posts, nil
+}
+
+// parseMediumPost fills in a post from one exported HTML file
+func parseMediumPost(content []byte, post *Post) error {
+	doc, err := html.Parse(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("invalid HTML: %w", err)
+	}
+
+	post.Status = "published"
+	var body *html.Node
+	walk(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		switch {
+		case hasClass(n, "p-name") && post.Title == "":
+			post.Title = strings.TrimSpace(textContent(n))
+		case hasClass(n, "p-summary"):
+			post.Summary = strings.TrimSpace(textContent(n))
+		case hasClass(n, "e-content"):
+			body = n
+			return false
+		case hasClass(n, "dt-published"):
+			if t, err := time.Parse(time.RFC3339, attr(n, "datetime")); err == nil {
+				t = t.UTC()
+				post.PublishedAt = &t
+			}
+		case hasClass(n, "p-canonical"):
+			post.CanonicalURL = attr(n, "href")
+		case hasClass(n, "p-tag"):
+			post.Tags = append(post.Tags, strings.TrimSpace(textContent(n)))
+		}
+		return true
+	})
+
+	if body == nil {
+		return fmt.Errorf("no post body found")
+	}
+
+	// Medium repeats the title and subtitle at the top of the body
+	walk(body, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && (hasClass(n, "graf--title") || hasClass(n, "graf--subtitle")) {
+			n.Parent.RemoveChild(n)
+			return false
+		}
+		return true
+	})
+
+	var buf bytes.Buffer
+	for c := body.FirstChild; c != nil; c = c.NextSibling {
+		if err := html.Render(&buf, c); err != nil {
+			return fmt.Errorf("invalid HTML: %w", err)
+		}
+	}
+	markdown, err := HTMLToMarkdown(buf.String())
+	if err != nil {
+		return err
+	}
+	post.Body = markdown
+	return nil
+}
+
+// walk visits n and its descendants depth first. Returning false from visit
+// skips a node's children.
+func walk(n *html.Node, visit func(*html.Node) bool) {
+	if !visit(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		walk(c, visit)
+		c = next
+	}
+}
@@ -0,0 +1,121 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const contentNamespace = "http://purl.org/rss/1.0/modules/content/"
+
+var blockTag = regexp.MustCompile(`(?i)^\s*<(p|div|h[1-6]|ul|ol|li|blockquote|pre|figure|table|hr|!--)[\s>/]`)
+
+type wxrFile struct {
+	Items []wxrItem `xml:"channel>item"`
+}
+
+type wxrItem struct {
+	Title      string        `xml:"title"`
+	Link       string        `xml:"link"`
+	GUID       string        `xml:"guid"`
+	PostID     string        `xml:"post_id"`
+	PostDate   string        `xml:"post_date_gmt"`
+	Status     string        `xml:"status"`
+	PostType   string        `xml:"post_type"`
+	Encoded    []wxrEncoded  `xml:"encoded"`
+	Categories []wxrCategory `xml:"category"`
+}
+
+// wxrEncoded is a content:encoded or excerpt:encoded element; they share a
+// local name and differ only by namespace
+type wxrEncoded struct {
+	XMLName xml.Name
+	Text    string `xml:",chardata"`
+}
+
+type wxrCategory struct {
+	Domain string `xml:"domain,attr"`
+	Name   string `xml:",chardata"`
+}
+
+// ParseWordPressExport reads the posts from a WordPress WXR export file. Pages,
+// attachments and trashed posts are returned with Skip set.
+func ParseWordPressExport(data []byte) ([]Post, error) {
+	var file wxrFile
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid WordPress export file: %w", err)
+	}
+
+	posts := make([]Post, 0, len(file.Items))
+	for _, item := range file.Items {
+		post := Post{
+			Key:   "wordpress:" + item.GUID,
+			Title: strings.TrimSpace(item.Title),
+		}
+		if item.Status == "publish" {
+			post.CanonicalURL = strings.TrimSpace(item.Link)
+		}
+		if item.GUID == "" {
+			post.Key = "wordpress:" + item.PostID
+		}
+
+		switch item.Status {
+		case "publish":
+			post.Status = "published"
+		case "draft", "pending", "private", "future":
+			post.Status = "draft"
+		default:
+			post.Skip = fmt.Sprintf("status %q is not imported", item.Status)
+		}
+		if item.PostType != "post" {
+			post.Skip = fmt.Sprintf("%s is not a post", item.PostType)
+		}
+
+		if t, err := time.Parse("2006-01-02 15:04:05", item.PostDate); err == nil && post.Status == "published" {
+			post.PublishedAt = &t
+		}
+
+		seen := make(map[string]bool)
+		for _, c := range item.Categories {
+			name := strings.TrimSpace(c.Name)
+			if (c.Domain != "post_tag" && c.Domain != "category") || name == "" || strings.EqualFold(name, "Uncategorized") {
+				continue
+			}
+			if key := strings.ToLower(name); !seen[key] {
+				seen[key] = true
+				post.Tags = append(post.Tags, name)
+			}
+		}
+
+		for _, e := range item.Encoded {
+			if e.XMLName.Space == contentNamespace {
+				body, err := HTMLToMarkdown(autoParagraph(e.Text))
+				if err != nil && post.Skip == "" {
+					post.Skip = err.Error()
+				}
+				post.Body = body
+			} else {
+				post.Summary = strings.TrimSpace(e.Text)
+			}
+		}
+
+		posts = append(posts, post)
+	}
+	return posts, nil
+}
+
+// autoParagraph wraps the blank-line separated text blocks WordPress stores
+// without markup in <p> tags, the way WordPress does when rendering
+func autoParagraph(content string) string {
+	blocks := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n\n")
+	for i, b := range blocks {
+		if strings.TrimSpace(b) == "" || blockTag.MatchString(b) {
+			continue
+		}
+		blocks[i] = "<p>" + strings.ReplaceAll(strings.TrimSpace(b), "\n", "<br>") + "</p>"
+	}
+	return strings.Join(blocks, "\n")
+}
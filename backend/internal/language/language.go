@@ -0,0 +1,106 @@
+// Package language identifies the language an article is written in, for
+// full-text search stemming.
+package language
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Default is the language assumed when none is given or detected
+const Default = "en"
+
+// stopwords are frequent function words of each supported language. Words
+// shared between languages (such as "de" or "en") are left out so they do
+// not skew detection.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "was", "of", "to", "in", "that", "it", "with", "for", "this", "you", "not", "have", "be", "on", "they", "what"},
+	"es": {"el", "los", "las", "del", "y", "que", "es", "por", "pero", "como", "más", "muy", "también", "son", "sus", "lo", "al", "esta", "fue", "hay"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "ein", "eine", "auch", "sich", "auf", "für", "dem", "den", "wir", "ich", "sind", "wie", "oder"},
+	"fr": {"le", "les", "et", "est", "une", "des", "du", "pour", "dans", "qui", "pas", "sur", "avec", "sont", "nous", "vous", "mais", "au", "ce", "cette"},
+	"pt": {"os", "da", "não", "com", "uma", "muito", "também", "são", "isso", "ao", "dos", "pelos", "mas", "foi", "nos", "pelo", "pela", "você", "ela", "em"},
+	"it": {"il", "gli", "della", "che", "è", "per", "non", "sono", "anche", "più", "questo", "nel", "alla", "ma", "dei", "delle", "essere", "degli", "questa", "ci"},
+	"nl": {"het", "een", "van", "en", "niet", "dat", "zijn", "op", "voor", "met", "ook", "maar", "wij", "naar", "bij", "dit", "wordt", "worden", "aan", "ik"},
+}
+
+// index maps each stopword to its language
+var index = func() map[string]string {
+	m := make(map[string]string)
+	for lang, words := range stopwords {
+		for _, w := range words {
+			m[w] = lang
+		}
+	}
+	return m
+}()
+
+// IsSupported reports whether lang is a supported language code
+func IsSupported(lang string) bool {
+	_, ok := stopwords[lang]
+	return ok
+}
+
+// Detect guesses the language of text by counting stopwords, returning
+// Default when no language stands out
+func Detect(text string) string {
+	counts := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, w := range words {
+		if lang, ok := index[w]; ok {
+			counts[lang]++
+		}
+	}
+
+	best, bestCount := Default, 0
+	for lang, n := range counts {
+		if n > bestCount || (n == bestCount && lang < best) {
+			best, bestCount = lang, n
+		}
+	}
+	if bestCount < 3 {
+		return Default
+	}
+	return best
+}
+
+// Negotiate picks the language from available that an Accept-Language header
+// prefers most, comparing primary subtags only (so "es-MX" matches "es"). It
+// returns "" when the header accepts none of them.
+func Negotiate(header string, available []string) string {
+	type preference struct {
+		lang string
+		q    float64
+	}
+
+	var prefs []preference
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = f
+		}
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if primary == "" || primary == "*" || q <= 0 {
+			continue
+		}
+		prefs = append(prefs, preference{primary, q})
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+
+	for _, p := range prefs {
+		for _, lang := range available {
+			if lang == p.lang {
+				return lang
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+// Package markdown reads and writes articles as Markdown files with a YAML
+// front matter header.
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const delimiter = "---"
+
+// ErrNoFrontMatter is returned when a file does not start with a front matter block
+var ErrNoFrontMatter = errors.New("missing front matter")
+
+// FrontMatter is the YAML header of an article file
+type FrontMatter struct {
+	ID          string     `yaml:"id,omitempty"`
+	Slug        string     `yaml:"slug,omitempty"`
+	Title       string     `yaml:"title"`
+	Summary     string     `yaml:"summary"`
+	Tags        []string   `yaml:"tags"`
+	Status      string     `yaml:"status"`
+	Thumbnail   string     `yaml:"thumbnail"`
+	Visibility  string     `yaml:"visibility"`
+	PublishedAt *time.Time `yaml:"published_at,omitempty"`
+	// CanonicalURL and OriginalPublishedAt describe where and when a
+	// cross-posted article first appeared
+	CanonicalURL        string     `yaml:"canonical_url,omitempty"`
+	OriginalPublishedAt *time.Time `yaml:"original_published_at,omitempty"`
+	// Language is detected from the text when omitted
+	Language string `yaml:"language,omitempty"`
+}
+
+// Document is an article file: front matter plus the Markdown body
+type Document struct {
+	FrontMatter
+	Body string
+}
+
+// Parse splits a file into its front matter and body. A leading byte order
+// mark is dropped and CRLF line endings become LF; otherwise the body is
+// returned byte for byte, so Render(Parse(x)) round trips for LF files.
+func Parse(data []byte) (Document, error) {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	if !strings.HasPrefix(text, delimiter+"\n") {
+		return Document{}, ErrNoFrontMatter
+	}
+	rest := text[len(delimiter)+1:]
+
+	var header, body string
+	if strings.HasPrefix(rest, delimiter+"\n") {
+		body = rest[len(delimiter)+1:]
+	} else if end := strings.Index(rest, "\n"+delimiter+"\n"); end >= 0 {
+		header, body = rest[:end+1], rest[end+len(delimiter)+2:]
+	} else if strings.HasSuffix(rest, "\n"+delimiter) {
+		header = rest[:len(rest)-len(delimiter)]
+	} else {
+		return Document{}, fmt.Errorf("unterminated front matter")
+	}
+
+	var doc Document
+	if err := yaml.Unmarshal([]byte(header), &doc.FrontMatter); err != nil {
+		return Document{}, fmt.Errorf("invalid front matter: %w", err)
+	}
+	doc.Body = body
+	return doc, nil
+}
+
+// Render writes a document in the format read by Parse
+func Render(doc Document) ([]byte, error) {
+	if doc.Tags == nil {
+		doc.Tags = []string{}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(delimiter + "\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc.FrontMatter); err != nil {
+		return nil, fmt.Errorf("failed to encode front matter: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode front matter: %w", err)
+	}
+	buf.WriteString(delimiter + "\n")
+	buf.WriteString(doc.Body)
+	return buf.Bytes(), nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jagjeevanak/golang-server/internal/config"
 	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
 )
 
 // AdminRoutes sets up admin-related routes
@@ -41,4 +42,185 @@ func AdminRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.Ap
 		cfg.FileserverHits.Swap(0)
 		w.WriteHeader(http.StatusOK)
 	})
+
+	// POST /api/admin/reconcile-counters - Recompute engagement counters and report drift (admin only)
+	mux.Handle("POST /api/admin/reconcile-counters", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !requireAdmin(w, r, dbQueries) {
+			return
+		}
+
+		// Articles and users are reconciled together or not at all
+		tx, err := cfg.DB.BeginTx(r.Context(), nil)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to reconcile counters")
+			return
+		}
+		defer tx.Rollback()
+		qtx := dbQueries.WithTx(tx)
+
+		articles, err := qtx.ReconcileArticleCounters(r.Context())
+		if err != nil {
+			log.Printf("Failed to reconcile article counters: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to reconcile article counters")
+			return
+		}
+
+		users, err := qtx.ReconcileUserCounters(r.Context())
+		if err != nil {
+			log.Printf("Failed to reconcile user counters: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to reconcile user counters")
+			return
+		}
+
+		if err := tx.Commit(); err != nil {
+			log.Printf("Failed to commit reconciled counters: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to reconcile counters")
+			return
+		}
+
+		articleDrift := make([]map[string]interface{}, 0, len(articles))
+		for _, a := range articles {
+			articleDrift = append(articleDrift, map[string]interface{}{
+				"id":            a.ID,
+				"title":         a.Title,
+				"clap_count":    map[string]int32{"stored": a.StoredClapCount, "actual": a.ClapCount},
+				"clapper_count": map[string]int32{"stored": a.StoredClapperCount, "actual": a.ClapperCount},
+				"comment_count": map[string]int32{"stored": a.StoredCommentCount, "actual": a.CommentCount},
+			})
+		}
+
+		userDrift := make([]map[string]interface{}, 0, len(users))
+		for _, u := range users {
+			userDrift = append(userDrift, map[string]interface{}{
+				"id":              u.ID,
+				"username":        nullStringToStr(u.Username),
+				"follower_count":  map[string]int32{"stored": u.StoredFollowerCount, "actual": u.FollowerCount},
+				"following_count": map[string]int32{"stored": u.StoredFollowingCount, "actual": u.FollowingCount},
+			})
+		}
+
+		if len(articleDrift) > 0 || len(userDrift) > 0 {
+			log.Printf("Reconciled counters: %d articles and %d users had drifted", len(articleDrift), len(userDrift))
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"articles": articleDrift,
+			"users":    userDrift,
+			"drifted":  len(articleDrift) + len(userDrift),
+		})
+	})))
+
+	// PUT /api/admin/users/{username}/membership - Grant or revoke membership (admin only)
+	mux.Handle("PUT /api/admin/users/{username}/membership", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !requireAdmin(w, r, dbQueries) {
+			return
+		}
+
+		type request struct {
+			IsMember *bool `json:"is_member"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.IsMember == nil {
+			respondError(w, http.StatusBadRequest, "is_member is required")
+			return
+		}
+
+		user, err := dbQueries.GetUserByUsername(r.Context(), sqlNullString(r.PathValue("username")))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "User not found")
+			return
+		}
+
+		user, err = dbQueries.SetUserMembership(r.Context(), database.SetUserMembershipParams{
+			ID:       user.ID,
+			IsMember: *req.IsMember,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to update membership")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"id":        user.ID,
+			"username":  nullStringToStr(user.Username),
+			"is_member": user.IsMember,
+		})
+	})))
+
+	// POST /api/admin/refresh-scores - Recompute trending and top article scores now (admin only)
+	mux.Handle("POST /api/admin/refresh-scores", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !requireAdmin(w, r, dbQueries) {
+			return
+		}
+
+		if err := refreshArticleScores(r.Context(), dbQueries); err != nil {
+			log.Printf("Failed to refresh article scores: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to refresh article scores")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Article scores refreshed"})
+	})))
+
+	// GET /api/admin/comments/{id}/history - A comment with its earlier versions, oldest first (admin only)
+	mux.Handle("GET /api/admin/comments/{id}/history", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !requireAdmin(w, r, dbQueries) {
+			return
+		}
+
+		commentID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid comment ID")
+			return
+		}
+
+		comment, err := dbQueries.GetCommentByID(r.Context(), commentID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Comment not found")
+			return
+		}
+
+		edits, err := dbQueries.ListCommentEdits(r.Context(), commentID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch comment history")
+			return
+		}
+
+		versions := make([]map[string]interface{}, 0, len(edits))
+		for _, e := range edits {
+			versions = append(versions, map[string]interface{}{
+				"id":          e.ID,
+				"body":        e.Body,
+				"replaced_at": e.ReplacedAt,
+			})
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"comment":  commentRowToResponse(comment),
+			"versions": versions,
+			"count":    len(versions),
+		})
+	})))
+}
+
+// requireAdmin reports whether the authenticated caller is an admin. It writes
+// the error response itself when they are not.
+func requireAdmin(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries) bool {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return false
+	}
+
+	user, err := dbQueries.GetUserByID(r.Context(), userID)
+	if err != nil || !user.IsAdmin {
+		respondError(w, http.StatusForbidden, "Admin access required")
+		return false
+	}
+	return true
 }
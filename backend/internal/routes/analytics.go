@@ -0,0 +1,277 @@
+package routes
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/auth"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+const (
+	// readScrollDepth is the fraction of an article a reader must scroll
+	// through for the read beacon to count
+	readScrollDepth = 0.75
+	// readTimeFraction is the fraction of the estimated reading time a reader
+	// must spend on an article for the read beacon to count
+	readTimeFraction = 0.3
+)
+
+// AnalyticsRoutes sets up read tracking and author stats routes
+func AnalyticsRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// POST /api/articles/{id}/read - Read beacon, sent after a scroll or time threshold (optional auth)
+	mux.Handle("POST /api/articles/{id}/read", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		type request struct {
+			ScrollDepth float64 `json:"scroll_depth"`
+			Seconds     int     `json:"seconds"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		article, err := dbQueries.GetArticleByID(r.Context(), id)
+		if err != nil || article.Status != "published" {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+
+		minSeconds := float64(article.ReadingTime) * 60 * readTimeFraction
+		if req.ScrollDepth < readScrollDepth && float64(req.Seconds) < minSeconds {
+			respondJSON(w, http.StatusOK, map[string]bool{"counted": false})
+			return
+		}
+
+		counted, err := dbQueries.RecordArticleRead(r.Context(), database.RecordArticleReadParams{
+			ArticleID:  id,
+			ViewerHash: viewerHash(r, cfg),
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to record read")
+			return
+		}
+
+		// A counted read marks the article finished in a signed-in reader's history
+		if userID, ok := middleware.GetUserID(r); ok {
+			err := dbQueries.RecordUserRead(r.Context(), database.RecordUserReadParams{
+				UserID:    userID,
+				ArticleID: id,
+			})
+			if err != nil {
+				log.Printf("Failed to record read of article %s: %v", id, err)
+			}
+		}
+
+		respondJSON(w, http.StatusOK, map[string]bool{"counted": counted > 0})
+	})))
+
+	// GET /api/articles/{id}/stats?days=30 - Daily views, reads and claps for an article (owner and co-authors)
+	mux.Handle("GET /api/articles/{id}/stats", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		since, ok := getStatsSince(r)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "days must be between 1 and 365")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, id, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if role != "owner" && role != "co-author" {
+			respondError(w, http.StatusForbidden, "Not authorized to view stats for this article")
+			return
+		}
+
+		rows, err := dbQueries.GetArticleDailyStats(r.Context(), database.GetArticleDailyStatsParams{
+			ArticleID: id,
+			Since:     since,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch stats")
+			return
+		}
+
+		var totals statsTotals
+		daily := make([]map[string]interface{}, 0, len(rows))
+		for _, d := range rows {
+			day := statsTotals{views: d.Views, reads: d.Reads, claps: d.Claps}
+			totals.add(day)
+			daily = append(daily, day.toResponse(d.Day))
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"article_id": id,
+			"since":      since.Format(time.DateOnly),
+			"totals":     totals.toResponse(time.Time{}),
+			"daily":      daily,
+		})
+	})))
+
+	// GET /api/users/me/stats?days=30 - Daily stats across own and co-authored articles plus followers gained (auth required)
+	mux.Handle("GET /api/users/me/stats", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		since, ok := getStatsSince(r)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "days must be between 1 and 365")
+			return
+		}
+
+		rows, err := dbQueries.GetUserDailyStats(r.Context(), database.GetUserDailyStatsParams{
+			UserID: userID,
+			Since:  since,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch stats")
+			return
+		}
+
+		articles, err := dbQueries.ListArticleStatsForUser(r.Context(), database.ListArticleStatsForUserParams{
+			UserID: userID,
+			Since:  since,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch stats")
+			return
+		}
+
+		var totals statsTotals
+		daily := make([]map[string]interface{}, 0, len(rows))
+		for _, d := range rows {
+			day := statsTotals{views: d.Views, reads: d.Reads, claps: d.Claps,
+				followersGained: d.FollowersGained, followersLost: d.FollowersLost}
+			totals.add(day)
+			item := day.toResponse(d.Day)
+			item["followers_gained"] = day.followersGained
+			item["followers_lost"] = day.followersLost
+			daily = append(daily, item)
+		}
+
+		perArticle := make([]map[string]interface{}, 0, len(articles))
+		for _, a := range articles {
+			item := statsTotals{views: a.Views, reads: a.Reads, claps: a.Claps}.toResponse(time.Time{})
+			item["id"] = a.ID
+			item["title"] = a.Title
+			item["published_at"] = nullTimeToPtr(a.PublishedAt)
+			perArticle = append(perArticle, item)
+		}
+
+		resp := totals.toResponse(time.Time{})
+		resp["followers_gained"] = totals.followersGained
+		resp["followers_lost"] = totals.followersLost
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"since":    since.Format(time.DateOnly),
+			"totals":   resp,
+			"daily":    daily,
+			"articles": perArticle,
+		})
+	})))
+}
+
+// recordArticleView counts a view of a published article, once per viewer
+// per day. Views by the article's own collaborators are not counted.
+// Analytics are best effort, so failures are only logged.
+func recordArticleView(r *http.Request, dbQueries *database.Queries, cfg *config.ApiConfig, articleID uuid.UUID) {
+	_, err := dbQueries.RecordArticleView(r.Context(), database.RecordArticleViewParams{
+		ArticleID:  articleID,
+		ViewerHash: viewerHash(r, cfg),
+	})
+	if err != nil {
+		log.Printf("Failed to record view of article %s: %v", articleID, err)
+	}
+}
+
+// viewerHash identifies the caller for today's view deduplication: signed-in
+// users by account, anonymous callers by IP address and user agent
+func viewerHash(r *http.Request, cfg *config.ApiConfig) string {
+	day := time.Now().UTC().Format(time.DateOnly)
+	if userID, ok := middleware.GetUserID(r); ok {
+		return auth.ViewerHash(cfg.JWTSecret, day, "user:"+userID.String())
+	}
+
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return auth.ViewerHash(cfg.JWTSecret, day, "anon:"+ip+"|"+r.UserAgent())
+}
+
+// getStatsSince reads the days query parameter (default 30, at most 365) and
+// returns the first UTC day of the period
+func getStatsSince(r *http.Request) (time.Time, bool) {
+	days := 30
+	if d := r.URL.Query().Get("days"); d != "" {
+		n, err := strconv.Atoi(d)
+		if err != nil || n < 1 || n > 365 {
+			return time.Time{}, false
+		}
+		days = n
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, 1-days), true
+}
+
+// statsTotals accumulates engagement counts over a day or a period
+type statsTotals struct {
+	views, reads, claps            int32
+	followersGained, followersLost int32
+}
+
+func (t *statsTotals) add(o statsTotals) {
+	t.views += o.views
+	t.reads += o.reads
+	t.claps += o.claps
+	t.followersGained += o.followersGained
+	t.followersLost += o.followersLost
+}
+
+// toResponse converts the counts to the JSON response format, with the day
+// they cover unless day is zero
+func (t statsTotals) toResponse(day time.Time) map[string]interface{} {
+	readRatio := 0.0
+	if t.views > 0 {
+		readRatio = float64(t.reads) / float64(t.views)
+	}
+
+	resp := map[string]interface{}{
+		"views":      t.views,
+		"reads":      t.reads,
+		"read_ratio": readRatio,
+		"claps":      t.claps,
+	}
+	if !day.IsZero() {
+		resp["day"] = day.Format(time.DateOnly)
+	}
+	return resp
+}
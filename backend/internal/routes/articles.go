@@ -1,13 +1,20 @@
 package routes
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/jagjeevanak/golang-server/internal/config"
 	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/language"
 	"github.com/jagjeevanak/golang-server/internal/middleware"
 )
 
@@ -27,7 +34,13 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 			Summary      string   `json:"summary"`
 			ThumbnailUrl string   `json:"thumbnail_url"`
 			Status       string   `json:"status"`
+			Visibility   string   `json:"visibility"`
 			Tags         []string `json:"tags"`
+			// Language is detected from the text when omitted
+			Language string `json:"language"`
+			// Cross-post metadata for articles that first appeared elsewhere
+			CanonicalUrl        string     `json:"canonical_url"`
+			OriginalPublishedAt *time.Time `json:"original_published_at"`
 		}
 
 		var req request
@@ -40,6 +53,10 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 			respondError(w, http.StatusBadRequest, "Title and body are required")
 			return
 		}
+		if msg := validateArticleTitle(req.Title); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
 
 		if req.Status == "" {
 			req.Status = "draft"
@@ -49,13 +66,38 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 			return
 		}
 
+		if req.Visibility == "" {
+			req.Visibility = "public"
+		}
+		if !isValidVisibility(req.Visibility) {
+			respondError(w, http.StatusBadRequest, "Visibility must be 'public' or 'members'")
+			return
+		}
+
+		if req.Language == "" {
+			req.Language = language.Detect(req.Title + "\n" + req.Body)
+		}
+		if !language.IsSupported(req.Language) {
+			respondError(w, http.StatusBadRequest, unsupportedLanguageMessage)
+			return
+		}
+
+		if msg := validateCrossPost(req.CanonicalUrl, req.OriginalPublishedAt); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+
 		article, err := dbQueries.CreateArticle(r.Context(), database.CreateArticleParams{
-			UserID:       userID,
-			Title:        req.Title,
-			Body:         req.Body,
-			Summary:      req.Summary,
-			ThumbnailUrl: req.ThumbnailUrl,
-			Status:       req.Status,
+			UserID:              userID,
+			Title:               req.Title,
+			Body:                req.Body,
+			Summary:             req.Summary,
+			ThumbnailUrl:        req.ThumbnailUrl,
+			Status:              req.Status,
+			Visibility:          req.Visibility,
+			CanonicalUrl:        req.CanonicalUrl,
+			OriginalPublishedAt: timePtrToNull(req.OriginalPublishedAt),
+			Language:            req.Language,
 		})
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to create article")
@@ -65,8 +107,7 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 		// If publishing immediately, update published_at
 		if req.Status == "published" {
 			article, err = dbQueries.PublishArticle(r.Context(), database.PublishArticleParams{
-				ID:     article.ID,
-				UserID: userID,
+				ID: article.ID,
 			})
 			if err != nil {
 				respondError(w, http.StatusInternalServerError, "Failed to publish article")
@@ -88,66 +129,141 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 			}
 		}
 
+		if article.Status == "published" {
+			invalidateRelatedArticles(r.Context(), dbQueries, article.ID)
+		}
+
 		// Fetch tags for response
 		tags, _ := dbQueries.GetArticleTags(r.Context(), article.ID)
 
 		respondJSON(w, http.StatusCreated, articleToResponse(article, tags, "", "", ""))
 	})))
 
-	// GET /api/articles - List published articles
-	mux.HandleFunc("GET /api/articles", func(w http.ResponseWriter, r *http.Request) {
+	// GET /api/articles?sort=latest|trending|top&window=day|week|month - List published articles
+	mux.Handle("GET /api/articles", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		fields, includeBody := getFieldSelection(r)
+
+		sort, window, ok := getRankingParams(r)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "sort must be 'latest', 'trending' or 'top' and window 'day', 'week' or 'month'")
+			return
+		}
 
 		// Check for author filter
 		author := r.URL.Query().Get("author")
+		if author != "" && sort != "latest" {
+			respondError(w, http.StatusBadRequest, "Author listings can only be sorted by latest")
+			return
+		}
+		if sort != "latest" {
+			respondRankedArticles(w, r, dbQueries, sort, window, sql.NullString{})
+			return
+		}
 		if author != "" {
 			articles, err := dbQueries.ListArticlesByAuthor(r.Context(), database.ListArticlesByAuthorParams{
-				Username: sql.NullString{String: author, Valid: true},
-				Limit:    limit,
-				Offset:   offset,
+				IncludeBody: includeBody,
+				Username:    sql.NullString{String: author, Valid: true},
+				Limit:       limit,
+				Offset:      offset,
+				CursorTime:  cursor.time(),
+				CursorID:    cursor.id(),
 			})
 			if err != nil {
 				respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
 				return
 			}
 
+			ids := make([]uuid.UUID, 0, len(articles))
+			for _, a := range articles {
+				ids = append(ids, a.ID)
+			}
+			extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+			bookmarked := getBookmarkedArticles(r, dbQueries, ids)
+			access := getReaderAccess(r, dbQueries)
+
 			result := make([]map[string]interface{}, 0, len(articles))
 			for _, a := range articles {
-				tags, _ := dbQueries.GetArticleTags(r.Context(), a.ID)
-				result = append(result, articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
+				item := articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
 					a.ThumbnailUrl, a.Status, a.PublishedAt, a.CreatedAt, a.UpdatedAt,
-					a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, tags))
+					a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, a.ReadingTime,
+					a.PublicationID, a.PublicationName, a.PublicationSlug,
+					extras.tags[a.ID], extras.coAuthors[a.ID])
+				item["comment_count"] = a.CommentCount
+				item["canonical_url"] = a.CanonicalUrl
+				item["language"] = a.Language
+				item["original_published_at"] = nullTimeToPtr(a.OriginalPublishedAt)
+				setBookmarked(item, bookmarked, a.ID)
+				applyVisibility(item, a.Visibility, a.Body, access.canRead(a.Visibility, a.UserID))
+				result = append(result, selectFields(item, fields, includeBody))
+			}
+			var nextCursor *string
+			if len(articles) == int(limit) {
+				last := articles[len(articles)-1]
+				nextCursor = encodeCursor(pageCursor{Time: last.PublishedAt.Time, ID: last.ID})
 			}
 			respondJSON(w, http.StatusOK, map[string]interface{}{
-				"articles": result,
-				"count":    len(result),
+				"articles":    result,
+				"count":       len(result),
+				"next_cursor": nextCursor,
 			})
 			return
 		}
 
 		articles, err := dbQueries.ListPublishedArticles(r.Context(), database.ListPublishedArticlesParams{
-			Limit:  limit,
-			Offset: offset,
+			IncludeBody: includeBody,
+			ViewerID:    getViewerID(r),
+			Limit:       limit,
+			Offset:      offset,
+			CursorTime:  cursor.time(),
+			CursorID:    cursor.id(),
 		})
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
 			return
 		}
 
+		ids := make([]uuid.UUID, 0, len(articles))
+		for _, a := range articles {
+			ids = append(ids, a.ID)
+		}
+		extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+		bookmarked := getBookmarkedArticles(r, dbQueries, ids)
+		access := getReaderAccess(r, dbQueries)
+
 		result := make([]map[string]interface{}, 0, len(articles))
 		for _, a := range articles {
-			tags, _ := dbQueries.GetArticleTags(r.Context(), a.ID)
-			result = append(result, articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
+			item := articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
 				a.ThumbnailUrl, a.Status, a.PublishedAt, a.CreatedAt, a.UpdatedAt,
-				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, tags))
+				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, a.ReadingTime,
+				a.PublicationID, a.PublicationName, a.PublicationSlug,
+				extras.tags[a.ID], extras.coAuthors[a.ID])
+			item["comment_count"] = a.CommentCount
+			item["canonical_url"] = a.CanonicalUrl
+			item["language"] = a.Language
+			item["original_published_at"] = nullTimeToPtr(a.OriginalPublishedAt)
+			setBookmarked(item, bookmarked, a.ID)
+			applyVisibility(item, a.Visibility, a.Body, access.canRead(a.Visibility, a.UserID))
+			result = append(result, selectFields(item, fields, includeBody))
+		}
+		var nextCursor *string
+		if len(articles) == int(limit) {
+			last := articles[len(articles)-1]
+			nextCursor = encodeCursor(pageCursor{Time: last.PublishedAt.Time, ID: last.ID})
 		}
 		respondJSON(w, http.StatusOK, map[string]interface{}{
-			"articles": result,
-			"count":    len(result),
+			"articles":    result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
 		})
-	})
+	})))
 
-	// GET /api/articles/feed - Feed from followed users (auth required)
+	// GET /api/articles/feed?finished=show|last|hide - Feed from followed users and publications (auth required)
 	mux.Handle("GET /api/articles/feed", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		userID, ok := middleware.GetUserID(r)
 		if !ok {
@@ -156,64 +272,143 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 		}
 
 		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		fields, includeBody := getFieldSelection(r)
+
+		finished, ok := getFinishedMode(r, "show")
+		if !ok {
+			respondError(w, http.StatusBadRequest, "finished must be 'show', 'last' or 'hide'")
+			return
+		}
 
 		articles, err := dbQueries.GetFeedArticles(r.Context(), database.GetFeedArticlesParams{
-			FollowerID: userID,
-			Limit:      limit,
-			Offset:     offset,
+			IncludeBody:    includeBody,
+			FollowerID:     userID,
+			Finished:       finished,
+			Limit:          limit,
+			Offset:         offset,
+			CursorTime:     cursor.time(),
+			CursorFinished: cursor.finished(),
+			CursorID:       cursor.id(),
 		})
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to fetch feed")
 			return
 		}
 
+		ids := make([]uuid.UUID, 0, len(articles))
+		for _, a := range articles {
+			ids = append(ids, a.ID)
+		}
+		extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+		bookmarked := getBookmarkedArticles(r, dbQueries, ids)
+		access := getReaderAccess(r, dbQueries)
+
 		result := make([]map[string]interface{}, 0, len(articles))
 		for _, a := range articles {
-			tags, _ := dbQueries.GetArticleTags(r.Context(), a.ID)
-			result = append(result, articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
+			item := articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
 				a.ThumbnailUrl, a.Status, a.PublishedAt, a.CreatedAt, a.UpdatedAt,
-				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, tags))
+				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, a.ReadingTime,
+				a.PublicationID, a.PublicationName, a.PublicationSlug,
+				extras.tags[a.ID], extras.coAuthors[a.ID])
+			item["comment_count"] = a.CommentCount
+			item["canonical_url"] = a.CanonicalUrl
+			item["language"] = a.Language
+			item["original_published_at"] = nullTimeToPtr(a.OriginalPublishedAt)
+			setBookmarked(item, bookmarked, a.ID)
+			item["finished"] = a.Finished
+			applyVisibility(item, a.Visibility, a.Body, access.canRead(a.Visibility, a.UserID))
+			result = append(result, selectFields(item, fields, includeBody))
+		}
+		var nextCursor *string
+		if len(articles) == int(limit) {
+			last := articles[len(articles)-1]
+			nextCursor = encodeCursor(pageCursor{Time: last.PublishedAt.Time, Finished: last.Finished, ID: last.ID})
 		}
 		respondJSON(w, http.StatusOK, map[string]interface{}{
-			"articles": result,
-			"count":    len(result),
+			"articles":    result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
 		})
 	})))
 
-	// GET /api/articles/search - Search articles
-	mux.HandleFunc("GET /api/articles/search", func(w http.ResponseWriter, r *http.Request) {
+	// GET /api/articles/search?q=&lang=es - Search articles, in one language or across all of them
+	mux.Handle("GET /api/articles/search", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		query := r.URL.Query().Get("q")
 		if query == "" {
 			respondError(w, http.StatusBadRequest, "Search query 'q' is required")
 			return
 		}
 
+		lang := r.URL.Query().Get("lang")
+		if lang != "" && !language.IsSupported(lang) {
+			respondError(w, http.StatusBadRequest, unsupportedLanguageMessage)
+			return
+		}
+
 		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		fields, includeBody := getFieldSelection(r)
 
 		articles, err := dbQueries.SearchArticles(r.Context(), database.SearchArticlesParams{
-			PlaintoTsquery: query,
-			Limit:          limit,
-			Offset:         offset,
+			IncludeBody: includeBody,
+			ViewerID:    getViewerID(r),
+			Query:       query,
+			Lang:        sqlNullString(lang),
+			Limit:       limit,
+			Offset:      offset,
+			CursorRank:  cursor.rank(),
+			CursorID:    cursor.id(),
 		})
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to search articles")
 			return
 		}
 
+		ids := make([]uuid.UUID, 0, len(articles))
+		for _, a := range articles {
+			ids = append(ids, a.ID)
+		}
+		extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+		bookmarked := getBookmarkedArticles(r, dbQueries, ids)
+		access := getReaderAccess(r, dbQueries)
+
 		result := make([]map[string]interface{}, 0, len(articles))
 		for _, a := range articles {
-			tags, _ := dbQueries.GetArticleTags(r.Context(), a.ID)
-			result = append(result, articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
+			item := articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
 				a.ThumbnailUrl, a.Status, a.PublishedAt, a.CreatedAt, a.UpdatedAt,
-				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, tags))
+				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, a.ReadingTime,
+				a.PublicationID, a.PublicationName, a.PublicationSlug,
+				extras.tags[a.ID], extras.coAuthors[a.ID])
+			item["comment_count"] = a.CommentCount
+			item["canonical_url"] = a.CanonicalUrl
+			item["language"] = a.Language
+			item["original_published_at"] = nullTimeToPtr(a.OriginalPublishedAt)
+			setBookmarked(item, bookmarked, a.ID)
+			applyVisibility(item, a.Visibility, a.Body, access.canRead(a.Visibility, a.UserID))
+			result = append(result, selectFields(item, fields, includeBody))
+		}
+		var nextCursor *string
+		if len(articles) == int(limit) {
+			last := articles[len(articles)-1]
+			nextCursor = encodeCursor(pageCursor{Rank: float64(last.Rank), ID: last.ID})
 		}
 		respondJSON(w, http.StatusOK, map[string]interface{}{
-			"articles": result,
-			"count":    len(result),
+			"articles":    result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
 		})
-	})
+	})))
 
-	// GET /api/articles/drafts - List own drafts (auth required)
+	// GET /api/articles/drafts - List own and shared drafts (auth required)
 	mux.Handle("GET /api/articles/drafts", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		userID, ok := middleware.GetUserID(r)
 		if !ok {
@@ -222,32 +417,63 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 		}
 
 		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		fields, includeBody := getFieldSelection(r)
 
 		articles, err := dbQueries.ListDraftsByUser(r.Context(), database.ListDraftsByUserParams{
-			UserID: userID,
-			Limit:  limit,
-			Offset: offset,
+			IncludeBody: includeBody,
+			UserID:      userID,
+			Limit:       limit,
+			Offset:      offset,
+			CursorTime:  cursor.time(),
+			CursorID:    cursor.id(),
 		})
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to fetch drafts")
 			return
 		}
 
+		ids := make([]uuid.UUID, 0, len(articles))
+		for _, a := range articles {
+			ids = append(ids, a.ID)
+		}
+		extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+		bookmarked := getBookmarkedArticles(r, dbQueries, ids)
+
 		result := make([]map[string]interface{}, 0, len(articles))
 		for _, a := range articles {
-			tags, _ := dbQueries.GetArticleTags(r.Context(), a.ID)
-			result = append(result, articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
+			item := articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
 				a.ThumbnailUrl, a.Status, a.PublishedAt, a.CreatedAt, a.UpdatedAt,
-				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, tags))
+				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, a.ReadingTime,
+				a.PublicationID, a.PublicationName, a.PublicationSlug,
+				extras.tags[a.ID], extras.coAuthors[a.ID])
+			item["comment_count"] = a.CommentCount
+			item["canonical_url"] = a.CanonicalUrl
+			item["language"] = a.Language
+			item["original_published_at"] = nullTimeToPtr(a.OriginalPublishedAt)
+			setBookmarked(item, bookmarked, a.ID)
+			applyVisibility(item, a.Visibility, a.Body, true)
+			result = append(result, selectFields(item, fields, includeBody))
+		}
+		var nextCursor *string
+		if len(articles) == int(limit) {
+			last := articles[len(articles)-1]
+			nextCursor = encodeCursor(pageCursor{Time: last.UpdatedAt, ID: last.ID})
 		}
 		respondJSON(w, http.StatusOK, map[string]interface{}{
-			"articles": result,
-			"count":    len(result),
+			"articles":    result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
 		})
 	})))
 
-	// GET /api/articles/{id} - Get single article
-	mux.HandleFunc("GET /api/articles/{id}", func(w http.ResponseWriter, r *http.Request) {
+	// GET /api/articles/{id}?negotiate=true - Get single article (drafts need a role or ?share= token; non-members get a preview of members-only articles).
+	// With negotiate=true, readers are redirected to the translation best matching their Accept-Language header.
+	mux.Handle("GET /api/articles/{id}", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		id, err := getPathID(r, "id")
 		if err != nil {
 			respondError(w, http.StatusBadRequest, "Invalid article ID")
@@ -260,18 +486,79 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 			return
 		}
 
+		// Drafts are only visible to their authors and collaborators, or through a share link
+		access := getReaderAccess(r, dbQueries)
+		var role string
+		if access.userID != uuid.Nil {
+			role, _ = articleRole(r.Context(), dbQueries, article.ID, access.userID)
+		}
+		_, shared := getShareLink(r, dbQueries, cfg, article.ID)
+		if article.Status == "draft" && role == "" && !shared {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+
+		translations, _ := dbQueries.ListArticleTranslations(r.Context(), database.ListArticleTranslationsParams{
+			ID:            article.ID,
+			IncludeDrafts: role != "",
+		})
+		if r.URL.Query().Get("negotiate") == "true" {
+			w.Header().Add("Vary", "Accept-Language")
+			if translationID, ok := negotiateTranslation(r, article.Language, translations); ok {
+				query := r.URL.Query()
+				query.Del("negotiate")
+				location := "/api/articles/" + translationID.String()
+				if len(query) > 0 {
+					location += "?" + query.Encode()
+				}
+				http.Redirect(w, r, location, http.StatusFound)
+				return
+			}
+		}
+
+		if article.Status == "published" && role == "" {
+			recordArticleView(r, dbQueries, cfg, article.ID)
+			if access.userID != uuid.Nil {
+				recordArticleOpen(r, dbQueries, access.userID, article.ID)
+			}
+		}
+
 		tags, _ := dbQueries.GetArticleTags(r.Context(), article.ID)
-		commentCount, _ := dbQueries.CountCommentsByArticle(r.Context(), article.ID)
+		coAuthors, _ := dbQueries.ListArticleCoAuthors(r.Context(), article.ID)
 
 		resp := articleRowToResponse(article.ID, article.UserID, article.Title, article.Body, article.Summary,
 			article.ThumbnailUrl, article.Status, article.PublishedAt, article.CreatedAt, article.UpdatedAt,
-			article.AuthorUsername, article.AuthorName, article.AuthorAvatarUrl, article.TotalClaps, tags)
-		resp["comment_count"] = commentCount
+			article.AuthorUsername, article.AuthorName, article.AuthorAvatarUrl, article.ClapCount, article.ReadingTime,
+			article.PublicationID, article.PublicationName, article.PublicationSlug, tags, coAuthors)
+		resp["comment_count"] = article.CommentCount
+		resp["clapper_count"] = article.ClapperCount
+		resp["canonical_url"] = article.CanonicalUrl
+		resp["language"] = article.Language
+		resp["translations"] = translationsToResponse(translations)
+		setBookmarked(resp, getBookmarkedArticles(r, dbQueries, []uuid.UUID{article.ID}), article.ID)
+		if access.userID != uuid.Nil {
+			// Where the reader left off, so clients can offer to resume
+			position, err := dbQueries.GetReadingPosition(r.Context(), database.GetReadingPositionParams{
+				UserID:    access.userID,
+				ArticleID: article.ID,
+			})
+			if err == nil {
+				resp["reading"] = readingPositionToResponse(position.Progress, position.FinishedAt, position.ReadAt)
+			}
+		}
+		resp["original_published_at"] = nullTimeToPtr(article.OriginalPublishedAt)
+
+		// Members-only articles are readable in full by members and collaborators,
+		// and by share link holders only while the article is a draft
+		canRead := access.canRead(article.Visibility, article.UserID) || role != "" ||
+			(shared && article.Status == "draft")
+		applyVisibility(resp, article.Visibility, article.Body, canRead)
 
+		w.Header().Set("ETag", etagFromRevision(article.Revision))
 		respondJSON(w, http.StatusOK, resp)
-	})
+	})))
 
-	// PUT /api/articles/{id} - Update own article (auth required)
+	// PUT /api/articles/{id} - Update article as owner, co-author or editor (auth required)
 	mux.Handle("PUT /api/articles/{id}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		userID, ok := middleware.GetUserID(r)
 		if !ok {
@@ -290,7 +577,13 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 			Body         string   `json:"body"`
 			Summary      string   `json:"summary"`
 			ThumbnailUrl string   `json:"thumbnail_url"`
+			Visibility   string   `json:"visibility"`
 			Tags         []string `json:"tags"`
+			// An omitted language keeps the current one
+			Language string `json:"language"`
+			// Omitted cross-post fields keep their current values
+			CanonicalUrl        *string    `json:"canonical_url"`
+			OriginalPublishedAt *time.Time `json:"original_published_at"`
 		}
 
 		var req request
@@ -299,35 +592,213 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 			return
 		}
 
+		if strings.TrimSpace(req.Title) == "" || strings.TrimSpace(req.Body) == "" {
+			respondError(w, http.StatusBadRequest, "Title and body are required")
+			return
+		}
+		if msg := validateArticleTitle(req.Title); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		// An omitted visibility keeps the current one
+		if req.Visibility != "" && !isValidVisibility(req.Visibility) {
+			respondError(w, http.StatusBadRequest, "Visibility must be 'public' or 'members'")
+			return
+		}
+		if req.Language != "" && !language.IsSupported(req.Language) {
+			respondError(w, http.StatusBadRequest, unsupportedLanguageMessage)
+			return
+		}
+
+		var canonicalUrl sql.NullString
+		if req.CanonicalUrl != nil {
+			canonicalUrl = sql.NullString{String: *req.CanonicalUrl, Valid: true}
+		}
+		if msg := validateCrossPost(canonicalUrl.String, req.OriginalPublishedAt); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		expectedRevision, ok := getIfMatchRevision(r)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "Invalid If-Match header")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, id, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if !canEditArticle(role) {
+			respondError(w, http.StatusForbidden, "Not authorized to edit this article")
+			return
+		}
+
+		existing, err := dbQueries.GetArticleByID(r.Context(), id)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+
 		article, err := dbQueries.UpdateArticle(r.Context(), database.UpdateArticleParams{
-			ID:           id,
-			Title:        req.Title,
-			Body:         req.Body,
-			Summary:      req.Summary,
-			ThumbnailUrl: req.ThumbnailUrl,
-			UserID:       userID,
+			ID:                  id,
+			Title:               req.Title,
+			Body:                req.Body,
+			Summary:             req.Summary,
+			ThumbnailUrl:        req.ThumbnailUrl,
+			Visibility:          sqlNullString(req.Visibility),
+			CanonicalUrl:        canonicalUrl,
+			OriginalPublishedAt: timePtrToNull(req.OriginalPublishedAt),
+			Language:            sqlNullString(req.Language),
+			ExpectedRevision:    expectedRevision,
 		})
+		if errors.Is(err, sql.ErrNoRows) && expectedRevision != 0 {
+			respondArticleConflict(w, r, dbQueries, id)
+			return
+		}
+		if isUniqueViolation(err) {
+			respondError(w, http.StatusConflict, "Another translation of this article is already in this language")
+			return
+		}
 		if err != nil {
-			respondError(w, http.StatusNotFound, "Article not found or not authorized")
+			respondError(w, http.StatusInternalServerError, "Failed to update article")
 			return
 		}
 
 		// Update tags: remove all existing, add new
 		if req.Tags != nil {
-			dbQueries.RemoveArticleTags(r.Context(), article.ID)
-			for _, tagName := range req.Tags {
-				tag, err := dbQueries.CreateTag(r.Context(), tagName)
-				if err != nil {
-					continue
-				}
-				dbQueries.AddArticleTag(r.Context(), database.AddArticleTagParams{
-					ArticleID: article.ID,
-					TagID:     tag.ID,
-				})
+			replaceArticleTags(r.Context(), dbQueries, article.ID, req.Tags)
+		}
+
+		if article.Body != existing.Body {
+			remapAnchorsAsync(dbQueries, article.ID)
+		}
+
+		tags, _ := dbQueries.GetArticleTags(r.Context(), article.ID)
+		w.Header().Set("ETag", etagFromRevision(article.Revision))
+		respondJSON(w, http.StatusOK, articleToResponse(article, tags, "", "", ""))
+	})))
+
+	// PATCH /api/articles/{id} - Partially update article with a JSON merge patch (auth required)
+	mux.Handle("PATCH /api/articles/{id}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		patch, err := decodeMergePatch(r, "title", "body", "summary", "thumbnail_url", "visibility", "tags",
+			"canonical_url", "original_published_at", "language")
+		if err != nil {
+			respondPatchError(w, err)
+			return
+		}
+
+		params := database.PatchArticleParams{ID: id}
+		for key, field := range map[string]*sql.NullString{
+			"title":         &params.Title,
+			"body":          &params.Body,
+			"summary":       &params.Summary,
+			"thumbnail_url": &params.ThumbnailUrl,
+			"visibility":    &params.Visibility,
+			"canonical_url": &params.CanonicalUrl,
+			"language":      &params.Language,
+		} {
+			if *field, err = patchString(patch, key); err != nil {
+				respondPatchError(w, err)
+				return
 			}
 		}
 
+		if params.Title.Valid {
+			if msg := validateArticleTitle(params.Title.String); msg != "" {
+				respondError(w, http.StatusBadRequest, msg)
+				return
+			}
+		}
+		if params.Body.Valid && strings.TrimSpace(params.Body.String) == "" {
+			respondError(w, http.StatusBadRequest, "Body cannot be empty")
+			return
+		}
+		if params.Visibility.Valid && !isValidVisibility(params.Visibility.String) {
+			respondError(w, http.StatusBadRequest, "Visibility must be 'public' or 'members'")
+			return
+		}
+		if params.Language.Valid && !language.IsSupported(params.Language.String) {
+			respondError(w, http.StatusBadRequest, unsupportedLanguageMessage)
+			return
+		}
+
+		if params.OriginalPublishedAt, params.SetOriginalPublishedAt, err = patchTime(patch, "original_published_at"); err != nil {
+			respondPatchError(w, err)
+			return
+		}
+		var originalPublishedAt *time.Time
+		if params.OriginalPublishedAt.Valid {
+			originalPublishedAt = &params.OriginalPublishedAt.Time
+		}
+		if msg := validateCrossPost(params.CanonicalUrl.String, originalPublishedAt); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		var newTags []string
+		if raw, ok := patch["tags"]; ok && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &newTags); err != nil {
+				respondError(w, http.StatusBadRequest, "Invalid patch: 'tags' must be an array of strings or null")
+				return
+			}
+		}
+
+		params.ExpectedRevision, ok = getIfMatchRevision(r)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "Invalid If-Match header")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, id, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if !canEditArticle(role) {
+			respondError(w, http.StatusForbidden, "Not authorized to edit this article")
+			return
+		}
+
+		article, err := dbQueries.PatchArticle(r.Context(), params)
+		if errors.Is(err, sql.ErrNoRows) && params.ExpectedRevision != 0 {
+			respondArticleConflict(w, r, dbQueries, id)
+			return
+		}
+		if isUniqueViolation(err) {
+			respondError(w, http.StatusConflict, "Another translation of this article is already in this language")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to update article")
+			return
+		}
+
+		// Arrays are replaced wholesale by a merge patch; null clears them
+		if _, ok := patch["tags"]; ok {
+			replaceArticleTags(r.Context(), dbQueries, article.ID, newTags)
+		}
+
+		if params.Body.Valid {
+			remapAnchorsAsync(dbQueries, article.ID)
+		}
+
 		tags, _ := dbQueries.GetArticleTags(r.Context(), article.ID)
+		w.Header().Set("ETag", etagFromRevision(article.Revision))
 		respondJSON(w, http.StatusOK, articleToResponse(article, tags, "", "", ""))
 	})))
 
@@ -345,19 +816,39 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 			return
 		}
 
-		err = dbQueries.DeleteArticle(r.Context(), database.DeleteArticleParams{
-			ID:     id,
-			UserID: userID,
+		expectedRevision, ok := getIfMatchRevision(r)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "Invalid If-Match header")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, id, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if role != "owner" {
+			respondError(w, http.StatusForbidden, "Only the article owner can delete it")
+			return
+		}
+
+		deleted, err := dbQueries.DeleteArticle(r.Context(), database.DeleteArticleParams{
+			ID:               id,
+			ExpectedRevision: expectedRevision,
 		})
 		if err != nil {
-			respondError(w, http.StatusNotFound, "Article not found or not authorized")
+			respondError(w, http.StatusInternalServerError, "Failed to delete article")
+			return
+		}
+		if deleted == 0 {
+			respondArticleConflict(w, r, dbQueries, id)
 			return
 		}
 
 		respondJSON(w, http.StatusOK, map[string]string{"message": "Article deleted successfully"})
 	})))
 
-	// POST /api/articles/{id}/publish - Publish a draft (auth required)
+	// POST /api/articles/{id}/publish - Publish a draft as owner or co-author (auth required)
 	mux.Handle("POST /api/articles/{id}/publish", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		userID, ok := middleware.GetUserID(r)
 		if !ok {
@@ -371,20 +862,128 @@ func ArticleRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 			return
 		}
 
+		expectedRevision, ok := getIfMatchRevision(r)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "Invalid If-Match header")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, id, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if !canPublishArticle(role) {
+			respondError(w, http.StatusForbidden, "Not authorized to publish this article")
+			return
+		}
+
+		// Drafts under review or accepted are published by the publication's editors
+		inReview, err := dbQueries.HasOpenSubmission(r.Context(), id)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to publish article")
+			return
+		}
+		if inReview {
+			respondError(w, http.StatusConflict, underReviewMessage)
+			return
+		}
+
 		article, err := dbQueries.PublishArticle(r.Context(), database.PublishArticleParams{
-			ID:     id,
-			UserID: userID,
+			ID:               id,
+			ExpectedRevision: expectedRevision,
 		})
+		if errors.Is(err, sql.ErrNoRows) && expectedRevision != 0 {
+			respondArticleConflict(w, r, dbQueries, id)
+			return
+		}
 		if err != nil {
-			respondError(w, http.StatusNotFound, "Article not found or not authorized")
+			respondError(w, http.StatusInternalServerError, "Failed to publish article")
 			return
 		}
 
+		invalidateRelatedArticles(r.Context(), dbQueries, article.ID)
+
 		tags, _ := dbQueries.GetArticleTags(r.Context(), article.ID)
+		w.Header().Set("ETag", etagFromRevision(article.Revision))
 		respondJSON(w, http.StatusOK, articleToResponse(article, tags, "", "", ""))
 	})))
 }
 
+// underReviewMessage is the error for publishing a draft that a publication
+// is reviewing or has accepted
+const underReviewMessage = "Article is submitted to a publication and is published by its editors"
+
+// unsupportedLanguageMessage is the error for an unknown article language
+const unsupportedLanguageMessage = "Language must be one of 'en', 'es', 'de', 'fr', 'pt', 'it' or 'nl'"
+
+// validateArticleTitle returns a message describing what is wrong with an
+// article title, or "" when it is valid
+func validateArticleTitle(title string) string {
+	if strings.TrimSpace(title) == "" {
+		return "Title cannot be empty"
+	}
+	if utf8.RuneCountInString(title) > 255 {
+		return "Title must be at most 255 characters"
+	}
+	return ""
+}
+
+// validateCrossPost checks an article's canonical URL and original publish
+// date, returning a message describing the problem or "" when they are valid.
+// An empty canonical URL means the article has none.
+func validateCrossPost(canonicalUrl string, originalPublishedAt *time.Time) string {
+	if canonicalUrl != "" && !isAbsoluteHTTPURL(canonicalUrl) {
+		return "canonical_url must be an absolute http(s) URL"
+	}
+	if originalPublishedAt != nil && originalPublishedAt.After(time.Now()) {
+		return "original_published_at cannot be in the future"
+	}
+	return ""
+}
+
+// isAbsoluteHTTPURL reports whether s is an absolute http or https URL with a host
+func isAbsoluteHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && len(s) <= 2048
+}
+
+// replaceArticleTags removes all tags from an article and attaches the given ones
+func replaceArticleTags(ctx context.Context, dbQueries *database.Queries, articleID uuid.UUID, tagNames []string) {
+	dbQueries.RemoveArticleTags(ctx, articleID)
+	for _, tagName := range tagNames {
+		tag, err := dbQueries.CreateTag(ctx, tagName)
+		if err != nil {
+			continue
+		}
+		dbQueries.AddArticleTag(ctx, database.AddArticleTagParams{
+			ArticleID: articleID,
+			TagID:     tag.ID,
+		})
+	}
+}
+
+// respondArticleConflict answers a failed If-Match precondition with the article's current version
+func respondArticleConflict(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, id uuid.UUID) {
+	article, err := dbQueries.GetArticleByID(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+
+	tags, _ := dbQueries.GetArticleTags(r.Context(), article.ID)
+	coAuthors, _ := dbQueries.ListArticleCoAuthors(r.Context(), article.ID)
+
+	current := articleRowToResponse(article.ID, article.UserID, article.Title, article.Body, article.Summary,
+		article.ThumbnailUrl, article.Status, article.PublishedAt, article.CreatedAt, article.UpdatedAt,
+		article.AuthorUsername, article.AuthorName, article.AuthorAvatarUrl, article.ClapCount, article.ReadingTime,
+		article.PublicationID, article.PublicationName, article.PublicationSlug, tags, coAuthors)
+	current["canonical_url"] = article.CanonicalUrl
+	current["original_published_at"] = nullTimeToPtr(article.OriginalPublishedAt)
+	applyVisibility(current, article.Visibility, article.Body, true)
+	respondPreconditionFailed(w, article.Revision, current)
+}
+
 // articleToResponse converts an Article model to a JSON-friendly response
 func articleToResponse(article database.Article, tags []database.Tag, authorUsername, authorName, authorAvatarUrl string) map[string]interface{} {
 	tagNames := make([]string, 0, len(tags))
@@ -393,17 +992,24 @@ func articleToResponse(article database.Article, tags []database.Tag, authorUser
 	}
 
 	resp := map[string]interface{}{
-		"id":            article.ID,
-		"user_id":       article.UserID,
-		"title":         article.Title,
-		"body":          article.Body,
-		"summary":       article.Summary,
-		"thumbnail_url": article.ThumbnailUrl,
-		"status":        article.Status,
-		"published_at":  nullTimeToPtr(article.PublishedAt),
-		"created_at":    article.CreatedAt,
-		"updated_at":    article.UpdatedAt,
-		"tags":          tagNames,
+		"id":                    article.ID,
+		"user_id":               article.UserID,
+		"title":                 article.Title,
+		"body":                  article.Body,
+		"summary":               article.Summary,
+		"thumbnail_url":         article.ThumbnailUrl,
+		"status":                article.Status,
+		"published_at":          nullTimeToPtr(article.PublishedAt),
+		"created_at":            article.CreatedAt,
+		"updated_at":            article.UpdatedAt,
+		"reading_time":          article.ReadingTime,
+		"visibility":            article.Visibility,
+		"original_published_at": nullTimeToPtr(article.OriginalPublishedAt),
+		"slug":                  nullStringToStr(article.Slug),
+		"canonical_url":         article.CanonicalUrl,
+		"language":              article.Language,
+		"tags":                  tagNames,
+		"publication":           publicationRef(article.PublicationID, sql.NullString{}, sql.NullString{}),
 	}
 
 	if authorUsername != "" {
@@ -425,8 +1031,11 @@ func articleRowToResponse(
 	createdAt, updatedAt time.Time,
 	authorUsername sql.NullString,
 	authorName, authorAvatarUrl string,
-	totalClaps int32,
+	totalClaps, readingTime int32,
+	publicationID uuid.NullUUID,
+	publicationName, publicationSlug sql.NullString,
 	tags []database.Tag,
+	coAuthors []database.ListArticleCoAuthorsRow,
 ) map[string]interface{} {
 	tagNames := make([]string, 0, len(tags))
 	for _, t := range tags {
@@ -445,15 +1054,53 @@ func articleRowToResponse(
 		"created_at":    createdAt,
 		"updated_at":    updatedAt,
 		"total_claps":   totalClaps,
+		"reading_time":  readingTime,
 		"tags":          tagNames,
-		"author": map[string]string{
+		"publication":   publicationRef(publicationID, publicationName, publicationSlug),
+		"author": map[string]interface{}{
 			"username":   nullStringToStr(authorUsername),
 			"name":       authorName,
 			"avatar_url": authorAvatarUrl,
+			"co_authors": coAuthorsToResponse(coAuthors),
 		},
 	}
 }
 
+// articleListExtras holds the tags and co-authors for a page of articles,
+// keyed by article ID
+type articleListExtras struct {
+	tags      map[uuid.UUID][]database.Tag
+	coAuthors map[uuid.UUID][]database.ListArticleCoAuthorsRow
+}
+
+// loadArticleListExtras fetches the per-article data shown in list responses
+// with one query each for the whole page rather than one per row
+func loadArticleListExtras(ctx context.Context, dbQueries *database.Queries, ids []uuid.UUID) articleListExtras {
+	extras := articleListExtras{
+		tags:      make(map[uuid.UUID][]database.Tag, len(ids)),
+		coAuthors: make(map[uuid.UUID][]database.ListArticleCoAuthorsRow, len(ids)),
+	}
+	if len(ids) == 0 {
+		return extras
+	}
+
+	tags, _ := dbQueries.GetTagsForArticles(ctx, ids)
+	for _, t := range tags {
+		extras.tags[t.ArticleID] = append(extras.tags[t.ArticleID], t.Tag)
+	}
+
+	coAuthors, _ := dbQueries.ListCoAuthorsForArticles(ctx, ids)
+	for _, c := range coAuthors {
+		extras.coAuthors[c.ArticleID] = append(extras.coAuthors[c.ArticleID], database.ListArticleCoAuthorsRow{
+			ID:        c.ID,
+			Username:  c.Username,
+			Name:      c.Name,
+			AvatarUrl: c.AvatarUrl,
+		})
+	}
+	return extras
+}
+
 // sqlNullString is a helper to create sql.NullString from a string
 func sqlNullString(s string) sql.NullString {
 	return sql.NullString{String: s, Valid: s != ""}
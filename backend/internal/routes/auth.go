@@ -226,6 +226,7 @@ func userResponse(user database.User) map[string]interface{} {
 		"name":       user.Name,
 		"bio":        user.Bio,
 		"avatar_url": user.AvatarUrl,
+		"is_member":  user.IsMember,
 		"created_at": user.CreatedAt,
 		"updated_at": user.UpdatedAt,
 	}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 
 	"github.com/jagjeevanak/golang-server/internal/config"
@@ -40,11 +41,21 @@ func ClapRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.Api
 			return
 		}
 
-		clap, err := dbQueries.UpsertClap(r.Context(), database.UpsertClapParams{
+		if _, ok := getVisibleArticle(w, r, dbQueries, articleID); !ok {
+			return
+		}
+
+		params := database.UpsertClapParams{
 			ArticleID: articleID,
 			UserID:    userID,
 			Count:     req.Count,
-		})
+		}
+		clap, err := dbQueries.UpsertClap(r.Context(), params)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Another first clap from this reader landed after the statement
+			// started; the retry sees its row
+			clap, err = dbQueries.UpsertClap(r.Context(), params)
+		}
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to clap")
 			return
@@ -67,6 +78,10 @@ func ClapRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.Api
 		}
 
 		totalClaps, err := dbQueries.GetArticleClapCount(r.Context(), articleID)
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to get clap count")
 			return
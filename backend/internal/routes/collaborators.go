@@ -0,0 +1,314 @@
+package routes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+// CollaboratorRoutes sets up article collaborator routes (invite, accept, remove)
+func CollaboratorRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// POST /api/articles/{id}/collaborators - Invite a collaborator (owner only)
+	mux.Handle("POST /api/articles/{id}/collaborators", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		type request struct {
+			Username string `json:"username"`
+			Role     string `json:"role"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.Username == "" {
+			respondError(w, http.StatusBadRequest, "Username is required")
+			return
+		}
+
+		if req.Role == "" {
+			req.Role = "co-author"
+		}
+		if req.Role != "co-author" && req.Role != "editor" && req.Role != "viewer" {
+			respondError(w, http.StatusBadRequest, "Role must be 'co-author', 'editor' or 'viewer'")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, articleID, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if role != "owner" {
+			respondError(w, http.StatusForbidden, "Only the article owner can invite collaborators")
+			return
+		}
+
+		invitee, err := dbQueries.GetUserByUsername(r.Context(), sqlNullString(req.Username))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "User not found")
+			return
+		}
+
+		if invitee.ID == userID {
+			respondError(w, http.StatusBadRequest, "Cannot invite yourself")
+			return
+		}
+
+		collaborator, err := dbQueries.InviteCollaborator(r.Context(), database.InviteCollaboratorParams{
+			ArticleID: articleID,
+			UserID:    invitee.ID,
+			Role:      req.Role,
+			InvitedBy: userID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusConflict, "User is already a collaborator on this article")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to invite collaborator")
+			return
+		}
+
+		respondJSON(w, http.StatusCreated, map[string]interface{}{
+			"article_id":  collaborator.ArticleID,
+			"user_id":     collaborator.UserID,
+			"username":    nullStringToStr(invitee.Username),
+			"role":        collaborator.Role,
+			"accepted_at": nullTimeToPtr(collaborator.AcceptedAt),
+			"created_at":  collaborator.CreatedAt,
+		})
+	})))
+
+	// GET /api/articles/{id}/collaborators - List collaborators (owner and collaborators)
+	mux.Handle("GET /api/articles/{id}/collaborators", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, articleID, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if role == "" {
+			respondError(w, http.StatusForbidden, "Not a collaborator on this article")
+			return
+		}
+
+		collaborators, err := dbQueries.ListArticleCollaborators(r.Context(), articleID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch collaborators")
+			return
+		}
+
+		result := make([]map[string]interface{}, 0, len(collaborators))
+		for _, c := range collaborators {
+			result = append(result, map[string]interface{}{
+				"user_id":     c.UserID,
+				"username":    nullStringToStr(c.Username),
+				"name":        c.Name,
+				"avatar_url":  c.AvatarUrl,
+				"role":        c.Role,
+				"accepted_at": nullTimeToPtr(c.AcceptedAt),
+				"created_at":  c.CreatedAt,
+			})
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"collaborators": result,
+			"count":         len(result),
+		})
+	})))
+
+	// POST /api/articles/{id}/collaborators/accept - Accept a pending invitation (auth required)
+	mux.Handle("POST /api/articles/{id}/collaborators/accept", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		collaborator, err := dbQueries.AcceptCollaboration(r.Context(), database.AcceptCollaborationParams{
+			ArticleID: articleID,
+			UserID:    userID,
+		})
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Invitation not found")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"article_id":  collaborator.ArticleID,
+			"user_id":     collaborator.UserID,
+			"role":        collaborator.Role,
+			"accepted_at": nullTimeToPtr(collaborator.AcceptedAt),
+			"created_at":  collaborator.CreatedAt,
+		})
+	})))
+
+	// DELETE /api/articles/{id}/collaborators/{username} - Remove a collaborator (owner) or leave/decline (self)
+	mux.Handle("DELETE /api/articles/{id}/collaborators/{username}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		target, err := dbQueries.GetUserByUsername(r.Context(), sqlNullString(r.PathValue("username")))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "User not found")
+			return
+		}
+
+		if target.ID != userID {
+			role, err := articleRole(r.Context(), dbQueries, articleID, userID)
+			if err != nil {
+				respondError(w, http.StatusNotFound, "Article not found")
+				return
+			}
+			if role != "owner" {
+				respondError(w, http.StatusForbidden, "Only the article owner can remove collaborators")
+				return
+			}
+		}
+
+		err = dbQueries.RemoveCollaborator(r.Context(), database.RemoveCollaboratorParams{
+			ArticleID: articleID,
+			UserID:    target.ID,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to remove collaborator")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Collaborator removed successfully"})
+	})))
+
+	// GET /api/users/me/invitations - List pending collaboration invitations (auth required)
+	mux.Handle("GET /api/users/me/invitations", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		invitations, err := dbQueries.ListPendingInvitations(r.Context(), userID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch invitations")
+			return
+		}
+
+		result := make([]map[string]interface{}, 0, len(invitations))
+		for _, inv := range invitations {
+			result = append(result, map[string]interface{}{
+				"article_id":    inv.ArticleID,
+				"article_title": inv.ArticleTitle,
+				"role":          inv.Role,
+				"invited_by": map[string]string{
+					"username": nullStringToStr(inv.InviterUsername),
+					"name":     inv.InviterName,
+				},
+				"created_at": inv.CreatedAt,
+			})
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"invitations": result,
+			"count":       len(result),
+		})
+	})))
+}
+
+// articleRole returns the caller's role on an article: "owner", an accepted
+// collaborator role, or "" when they have no special access. It returns an
+// error when the article does not exist.
+func articleRole(ctx context.Context, dbQueries *database.Queries, articleID, userID uuid.UUID) (string, error) {
+	return dbQueries.GetArticleRole(ctx, database.GetArticleRoleParams{
+		ID:     articleID,
+		UserID: userID,
+	})
+}
+
+// getVisibleArticle loads an article the caller may see: any published
+// article, and drafts only for their collaborators and the editors reviewing
+// them. It writes a 404 response when there is none.
+func getVisibleArticle(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, articleID uuid.UUID) (database.GetArticleByIDRow, bool) {
+	article, err := dbQueries.GetArticleByID(r.Context(), articleID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return database.GetArticleByIDRow{}, false
+	}
+	if article.Status == "draft" {
+		var role string
+		if userID, ok := middleware.GetUserID(r); ok {
+			role, _ = articleRole(r.Context(), dbQueries, articleID, userID)
+		}
+		if role == "" {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return database.GetArticleByIDRow{}, false
+		}
+	}
+	return article, true
+}
+
+// canEditArticle reports whether a role may change an article's content
+func canEditArticle(role string) bool {
+	return role == "owner" || role == "co-author" || role == "editor"
+}
+
+// canPublishArticle reports whether a role may publish an article
+func canPublishArticle(role string) bool {
+	return role == "owner" || role == "co-author"
+}
+
+// coAuthorsToResponse converts accepted co-authors to the author block format
+func coAuthorsToResponse(coAuthors []database.ListArticleCoAuthorsRow) []map[string]string {
+	result := make([]map[string]string, 0, len(coAuthors))
+	for _, c := range coAuthors {
+		result = append(result, map[string]string{
+			"username":   nullStringToStr(c.Username),
+			"name":       c.Name,
+			"avatar_url": c.AvatarUrl,
+		})
+	}
+	return result
+}
@@ -1,8 +1,13 @@
 package routes
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jagjeevanak/golang-server/internal/config"
 	"github.com/jagjeevanak/golang-server/internal/database"
 	"github.com/jagjeevanak/golang-server/internal/middleware"
@@ -10,7 +15,7 @@ import (
 
 // CommentRoutes sets up comment-related routes
 func CommentRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
-	// POST /api/articles/{id}/comments - Add comment (auth required)
+	// POST /api/articles/{id}/comments - Add comment, or a reply with parent_id (auth required)
 	mux.Handle("POST /api/articles/{id}/comments", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		userID, ok := middleware.GetUserID(r)
 		if !ok {
@@ -25,7 +30,8 @@ func CommentRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 		}
 
 		type request struct {
-			Body string `json:"body"`
+			Body     string    `json:"body"`
+			ParentID uuid.UUID `json:"parent_id"`
 		}
 
 		var req request
@@ -39,10 +45,36 @@ func CommentRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 			return
 		}
 
+		if _, ok := getVisibleArticle(w, r, dbQueries, articleID); !ok {
+			return
+		}
+
+		var parentID uuid.NullUUID
+		var depth int32
+		if req.ParentID != uuid.Nil {
+			parent, err := dbQueries.GetCommentByID(r.Context(), req.ParentID)
+			if err != nil || parent.ArticleID != articleID {
+				respondError(w, http.StatusNotFound, "Parent comment not found")
+				return
+			}
+			if parent.DeletedAt.Valid {
+				respondError(w, http.StatusBadRequest, "Cannot reply to a deleted comment")
+				return
+			}
+			if int(parent.Depth) >= cfg.CommentMaxDepth {
+				respondError(w, http.StatusBadRequest, fmt.Sprintf("Replies can be nested at most %d levels deep", cfg.CommentMaxDepth))
+				return
+			}
+			parentID = uuid.NullUUID{UUID: parent.ID, Valid: true}
+			depth = parent.Depth + 1
+		}
+
 		comment, err := dbQueries.CreateComment(r.Context(), database.CreateCommentParams{
 			ArticleID: articleID,
 			UserID:    userID,
 			Body:      req.Body,
+			ParentID:  parentID,
+			Depth:     depth,
 		})
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to create comment")
@@ -57,6 +89,8 @@ func CommentRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 				"id":         comment.ID,
 				"article_id": comment.ArticleID,
 				"user_id":    comment.UserID,
+				"parent_id":  comment.ParentID,
+				"depth":      comment.Depth,
 				"body":       comment.Body,
 				"created_at": comment.CreatedAt,
 				"updated_at": comment.UpdatedAt,
@@ -67,20 +101,32 @@ func CommentRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 		respondJSON(w, http.StatusCreated, commentRowToResponse(fullComment))
 	})))
 
-	// GET /api/articles/{id}/comments - List comments for article
-	mux.HandleFunc("GET /api/articles/{id}/comments", func(w http.ResponseWriter, r *http.Request) {
+	// GET /api/articles/{id}/comments - List top-level comments for article with reply counts, collapsing those by muted authors (optional auth)
+	mux.Handle("GET /api/articles/{id}/comments", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		articleID, err := getPathID(r, "id")
 		if err != nil {
 			respondError(w, http.StatusBadRequest, "Invalid article ID")
 			return
 		}
 
+		if _, ok := getVisibleArticle(w, r, dbQueries, articleID); !ok {
+			return
+		}
+
 		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
 
 		comments, err := dbQueries.ListCommentsByArticle(r.Context(), database.ListCommentsByArticleParams{
-			ArticleID: articleID,
-			Limit:     limit,
-			Offset:    offset,
+			ArticleID:  articleID,
+			ViewerID:   getViewerID(r),
+			Limit:      limit,
+			Offset:     offset,
+			CursorTime: cursor.time(),
+			CursorID:   cursor.id(),
 		})
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to fetch comments")
@@ -89,28 +135,163 @@ func CommentRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 
 		result := make([]map[string]interface{}, 0, len(comments))
 		for _, c := range comments {
-			result = append(result, map[string]interface{}{
-				"id":         c.ID,
-				"article_id": c.ArticleID,
-				"user_id":    c.UserID,
-				"body":       c.Body,
-				"created_at": c.CreatedAt,
-				"updated_at": c.UpdatedAt,
-				"author": map[string]string{
-					"username":   nullStringToStr(c.AuthorUsername),
-					"name":       c.AuthorName,
-					"avatar_url": c.AuthorAvatarUrl,
-				},
-			})
+			item := commentToResponse(c.ID, c.ArticleID, c.UserID, c.ParentID, c.Depth, c.Body,
+				c.DeletedAt, c.CreatedAt, c.UpdatedAt, c.EditedAt,
+				c.AuthorUsername, c.AuthorName, c.AuthorAvatarUrl, c.ReplyCount)
+			item["collapsed"] = c.Collapsed && !c.DeletedAt.Valid
+			result = append(result, item)
+		}
+
+		var nextCursor *string
+		if len(comments) == int(limit) {
+			last := comments[len(comments)-1]
+			nextCursor = encodeCursor(pageCursor{Time: last.CreatedAt, ID: last.ID})
+		}
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"comments":    result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
+		})
+	})))
+
+	// GET /api/comments/{id}/replies - List direct replies to a comment, collapsing those by muted authors (optional auth)
+	mux.Handle("GET /api/comments/{id}/replies", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commentID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid comment ID")
+			return
 		}
 
+		comment, err := dbQueries.GetCommentByID(r.Context(), commentID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Comment not found")
+			return
+		}
+		if _, ok := getVisibleArticle(w, r, dbQueries, comment.ArticleID); !ok {
+			return
+		}
+
+		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+
+		replies, err := dbQueries.ListCommentReplies(r.Context(), database.ListCommentRepliesParams{
+			ParentID:   uuid.NullUUID{UUID: commentID, Valid: true},
+			ViewerID:   getViewerID(r),
+			Limit:      limit,
+			Offset:     offset,
+			CursorTime: cursor.time(),
+			CursorID:   cursor.id(),
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch replies")
+			return
+		}
+
+		result := make([]map[string]interface{}, 0, len(replies))
+		for _, c := range replies {
+			item := commentToResponse(c.ID, c.ArticleID, c.UserID, c.ParentID, c.Depth, c.Body,
+				c.DeletedAt, c.CreatedAt, c.UpdatedAt, c.EditedAt,
+				c.AuthorUsername, c.AuthorName, c.AuthorAvatarUrl, c.ReplyCount)
+			item["collapsed"] = c.Collapsed && !c.DeletedAt.Valid
+			result = append(result, item)
+		}
+
+		var nextCursor *string
+		if len(replies) == int(limit) {
+			last := replies[len(replies)-1]
+			nextCursor = encodeCursor(pageCursor{Time: last.CreatedAt, ID: last.ID})
+		}
 		respondJSON(w, http.StatusOK, map[string]interface{}{
-			"comments": result,
-			"count":    len(result),
+			"replies":     result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
+		})
+	})))
+
+	// PUT /api/comments/{id} - Edit own comment, within the edit window if one is configured (auth required)
+	mux.Handle("PUT /api/comments/{id}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		commentID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid comment ID")
+			return
+		}
+
+		type request struct {
+			Body string `json:"body"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.Body == "" {
+			respondError(w, http.StatusBadRequest, "Comment body is required")
+			return
+		}
+
+		comment, err := dbQueries.GetCommentByID(r.Context(), commentID)
+		if err != nil || comment.DeletedAt.Valid {
+			respondError(w, http.StatusNotFound, "Comment not found")
+			return
+		}
+		if comment.UserID != userID {
+			respondError(w, http.StatusForbidden, "Not authorized to edit this comment")
+			return
+		}
+
+		// An unchanged body is not an edit
+		if req.Body == comment.Body {
+			respondJSON(w, http.StatusOK, commentRowToResponse(comment))
+			return
+		}
+
+		// The edit window is checked against the database clock by the update
+		// itself; the comment was just found and is the caller's, so no row
+		// means the window has closed
+		var editWindow sql.NullFloat64
+		if cfg.CommentEditWindow > 0 {
+			editWindow = sql.NullFloat64{Float64: cfg.CommentEditWindow.Seconds(), Valid: true}
+		}
+		_, err = dbQueries.UpdateComment(r.Context(), database.UpdateCommentParams{
+			ID:                commentID,
+			UserID:            userID,
+			Body:              req.Body,
+			EditWindowSeconds: editWindow,
 		})
-	})
+		if errors.Is(err, sql.ErrNoRows) && editWindow.Valid {
+			respondError(w, http.StatusForbidden, fmt.Sprintf("Comments can only be edited within %s of posting", cfg.CommentEditWindow))
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "Comment not found")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to update comment")
+			return
+		}
+
+		updated, err := dbQueries.GetCommentByID(r.Context(), commentID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch comment")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, commentRowToResponse(updated))
+	})))
 
-	// DELETE /api/comments/{id} - Delete own comment (auth required)
+	// DELETE /api/comments/{id} - Delete own comment, leaving a placeholder if it has replies (auth required)
 	mux.Handle("DELETE /api/comments/{id}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		userID, ok := middleware.GetUserID(r)
 		if !ok {
@@ -124,11 +305,15 @@ func CommentRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 			return
 		}
 
-		err = dbQueries.DeleteComment(r.Context(), database.DeleteCommentParams{
+		deleted, err := dbQueries.DeleteComment(r.Context(), database.DeleteCommentParams{
 			ID:     commentID,
 			UserID: userID,
 		})
 		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to delete comment")
+			return
+		}
+		if deleted == 0 {
 			respondError(w, http.StatusNotFound, "Comment not found or not authorized")
 			return
 		}
@@ -137,18 +322,40 @@ func CommentRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.
 	})))
 }
 
+// commentRowToResponse converts a single comment to the JSON response format
 func commentRowToResponse(c database.GetCommentByIDRow) map[string]interface{} {
-	return map[string]interface{}{
-		"id":         c.ID,
-		"article_id": c.ArticleID,
-		"user_id":    c.UserID,
-		"body":       c.Body,
-		"created_at": c.CreatedAt,
-		"updated_at": c.UpdatedAt,
+	return commentToResponse(c.ID, c.ArticleID, c.UserID, c.ParentID, c.Depth, c.Body,
+		c.DeletedAt, c.CreatedAt, c.UpdatedAt, c.EditedAt,
+		c.AuthorUsername, c.AuthorName, c.AuthorAvatarUrl, c.ReplyCount)
+}
+
+// commentToResponse builds a comment response from any comment row. Deleted
+// comments keep their place in the thread but lose their body and author.
+func commentToResponse(id, articleID, userID uuid.UUID, parentID uuid.NullUUID, depth int32, body string,
+	deletedAt sql.NullTime, createdAt, updatedAt time.Time, editedAt sql.NullTime,
+	authorUsername sql.NullString, authorName, authorAvatarURL string, replyCount int32) map[string]interface{} {
+	resp := map[string]interface{}{
+		"id":          id,
+		"article_id":  articleID,
+		"user_id":     userID,
+		"parent_id":   parentID,
+		"depth":       depth,
+		"body":        body,
+		"reply_count": replyCount,
+		"deleted":     deletedAt.Valid,
+		"created_at":  createdAt,
+		"updated_at":  updatedAt,
+		"edited_at":   nullTimeToPtr(editedAt),
 		"author": map[string]string{
-			"username":   nullStringToStr(c.AuthorUsername),
-			"name":       c.AuthorName,
-			"avatar_url": c.AuthorAvatarUrl,
+			"username":   nullStringToStr(authorUsername),
+			"name":       authorName,
+			"avatar_url": authorAvatarURL,
 		},
 	}
+	if deletedAt.Valid {
+		resp["body"] = "[deleted]"
+		resp["user_id"] = nil
+		resp["author"] = nil
+	}
+	return resp
 }
@@ -94,11 +94,18 @@ func FollowRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.A
 		}
 
 		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
 
 		followers, err := dbQueries.ListFollowers(r.Context(), database.ListFollowersParams{
 			FollowingID: user.ID,
 			Limit:       limit,
 			Offset:      offset,
+			CursorTime:  cursor.time(),
+			CursorID:    cursor.id(),
 		})
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to fetch followers")
@@ -116,9 +123,15 @@ func FollowRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.A
 			})
 		}
 
+		var nextCursor *string
+		if len(followers) == int(limit) {
+			last := followers[len(followers)-1]
+			nextCursor = encodeCursor(pageCursor{Time: last.FollowedAt, ID: last.ID})
+		}
 		respondJSON(w, http.StatusOK, map[string]interface{}{
-			"followers": result,
-			"count":     len(result),
+			"followers":   result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
 		})
 	})
 
@@ -137,11 +150,18 @@ func FollowRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.A
 		}
 
 		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
 
 		following, err := dbQueries.ListFollowing(r.Context(), database.ListFollowingParams{
 			FollowerID: user.ID,
 			Limit:      limit,
 			Offset:     offset,
+			CursorTime: cursor.time(),
+			CursorID:   cursor.id(),
 		})
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to fetch following")
@@ -159,9 +179,15 @@ func FollowRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.A
 			})
 		}
 
+		var nextCursor *string
+		if len(following) == int(limit) {
+			last := following[len(following)-1]
+			nextCursor = encodeCursor(pageCursor{Time: last.FollowedAt, ID: last.ID})
+		}
 		respondJSON(w, http.StatusOK, map[string]interface{}{
-			"following": result,
-			"count":     len(result),
+			"following":   result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
 		})
 	})
 }
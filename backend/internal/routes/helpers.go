@@ -2,13 +2,19 @@ package routes
 
 import (
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // respondJSON writes a JSON response with the given status code
@@ -29,18 +35,104 @@ func respondError(w http.ResponseWriter, status int, msg string) {
 	respondJSON(w, status, map[string]string{"error": msg})
 }
 
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
 // decodeJSON decodes a JSON request body into the target struct
 func decodeJSON(r *http.Request, target interface{}) error {
 	return json.NewDecoder(r.Body).Decode(target)
 }
 
+// errUnsupportedPatchType is returned by decodeMergePatch for non-JSON request bodies
+var errUnsupportedPatchType = errors.New("unsupported patch content type")
+
+// decodeMergePatch decodes an RFC 7396 JSON merge patch document into its
+// top-level members, rejecting any member not listed in allowed.
+func decodeMergePatch(r *http.Request, allowed ...string) (map[string]json.RawMessage, error) {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mediaType != "application/merge-patch+json" && mediaType != "application/json") {
+			return nil, errUnsupportedPatchType
+		}
+	}
+
+	var patch map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil || patch == nil {
+		return nil, errors.New("patch must be a JSON object")
+	}
+
+	for key := range patch {
+		known := false
+		for _, a := range allowed {
+			if key == a {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown field '%s'", key)
+		}
+	}
+	return patch, nil
+}
+
+// respondPatchError writes the response for a decodeMergePatch or patchString error
+func respondPatchError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errUnsupportedPatchType) {
+		respondError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/merge-patch+json")
+		return
+	}
+	respondError(w, http.StatusBadRequest, "Invalid patch: "+err.Error())
+}
+
+// patchString reads a merge-patch member for a string column. An absent member
+// yields an invalid NullString (leave unchanged), null clears the column, and a
+// string replaces it.
+func patchString(patch map[string]json.RawMessage, key string) (sql.NullString, error) {
+	raw, ok := patch[key]
+	if !ok {
+		return sql.NullString{}, nil
+	}
+	if string(raw) == "null" {
+		return sql.NullString{String: "", Valid: true}, nil
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return sql.NullString{}, fmt.Errorf("'%s' must be a string or null", key)
+	}
+	return sql.NullString{String: value, Valid: true}, nil
+}
+
+// patchTime reads a merge-patch member for a nullable timestamp column. The
+// returned bool is false when the member is absent (leave unchanged); null
+// clears the column and an RFC 3339 string replaces it.
+func patchTime(patch map[string]json.RawMessage, key string) (sql.NullTime, bool, error) {
+	raw, ok := patch[key]
+	if !ok {
+		return sql.NullTime{}, false, nil
+	}
+	if string(raw) == "null" {
+		return sql.NullTime{}, true, nil
+	}
+	var value time.Time
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return sql.NullTime{}, false, fmt.Errorf("'%s' must be an RFC 3339 timestamp or null", key)
+	}
+	return sql.NullTime{Time: value.UTC(), Valid: true}, true, nil
+}
+
 // getPathID extracts a UUID from the last segment of the URL path
 func getPathID(r *http.Request, name string) (uuid.UUID, error) {
 	idStr := r.PathValue(name)
 	return uuid.Parse(idStr)
 }
 
-// getPagination extracts limit and offset from query parameters with defaults
+// getPagination extracts limit and offset from query parameters with defaults.
+// Offset is a deprecated fallback for clients that don't send a cursor; it is
+// ignored when a cursor is present.
 func getPagination(r *http.Request) (limit, offset int32) {
 	limit = 20
 	offset = 0
@@ -50,6 +142,9 @@ func getPagination(r *http.Request) (limit, offset int32) {
 			limit = int32(parsed)
 		}
 	}
+	if r.URL.Query().Get("cursor") != "" {
+		return
+	}
 	if o := r.URL.Query().Get("offset"); o != "" {
 		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
 			offset = int32(parsed)
@@ -58,6 +153,154 @@ func getPagination(r *http.Request) (limit, offset int32) {
 	return
 }
 
+// pageCursor is the decoded form of the opaque cursor token that list
+// endpoints return as next_cursor. It holds the sort key of the last row on a
+// page (a timestamp, a rank for search and rankings, or a reading list
+// position) with the row ID as a tiebreaker. Ranked lists keep the generation
+// of scores they page through in the timestamp.
+// Feeds listing finished articles last also record whether that row was finished.
+type pageCursor struct {
+	Time     time.Time `json:"t"`
+	Rank     float64   `json:"r,omitempty"`
+	Finished bool      `json:"f,omitempty"`
+	Position int32     `json:"p,omitempty"`
+	ID       uuid.UUID `json:"id"`
+}
+
+// getCursor decodes the cursor query parameter. It returns nil when the
+// parameter is absent.
+func getCursor(r *http.Request) (*pageCursor, error) {
+	raw := r.URL.Query().Get("cursor")
+	if raw == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+	var c pageCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	if c.ID == uuid.Nil {
+		return nil, errors.New("cursor is missing an id")
+	}
+	return &c, nil
+}
+
+// encodeCursor returns the opaque token for a cursor
+func encodeCursor(c pageCursor) *string {
+	data, _ := json.Marshal(c)
+	token := base64.RawURLEncoding.EncodeToString(data)
+	return &token
+}
+
+// time returns the cursor timestamp as a query argument (NULL for the first page)
+func (c *pageCursor) time() sql.NullTime {
+	if c == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: c.Time, Valid: true}
+}
+
+// rank returns the cursor search rank as a query argument (NULL for the first page)
+func (c *pageCursor) rank() sql.NullFloat64 {
+	if c == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: c.Rank, Valid: true}
+}
+
+// position returns the cursor reading list position as a query argument (NULL for the first page)
+func (c *pageCursor) position() sql.NullInt32 {
+	if c == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: c.Position, Valid: true}
+}
+
+// finished reports whether the cursor row was finished by the viewer (false for the first page)
+func (c *pageCursor) finished() bool {
+	return c != nil && c.Finished
+}
+
+// id returns the cursor tiebreaker ID as a query argument (NULL for the first page)
+func (c *pageCursor) id() uuid.NullUUID {
+	if c == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: c.ID, Valid: true}
+}
+
+// getFieldSelection parses the sparse fieldset (fields=title,summary) and
+// include=body query parameters accepted by article list endpoints. The full
+// body is only loaded when it is included or named in the fieldset.
+func getFieldSelection(r *http.Request) (fields map[string]bool, includeBody bool) {
+	if f := r.URL.Query().Get("fields"); f != "" {
+		fields = make(map[string]bool)
+		for _, name := range strings.Split(f, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				fields[name] = true
+			}
+		}
+	}
+	for _, inc := range strings.Split(r.URL.Query().Get("include"), ",") {
+		if strings.TrimSpace(inc) == "body" {
+			includeBody = true
+		}
+	}
+	return fields, includeBody || fields["body"]
+}
+
+// selectFields trims a list item to the requested sparse fieldset. The body is
+// dropped unless it was requested, and the id is always kept.
+func selectFields(item map[string]interface{}, fields map[string]bool, includeBody bool) map[string]interface{} {
+	if !includeBody {
+		delete(item, "body")
+	}
+	if len(fields) == 0 {
+		return item
+	}
+	for key := range item {
+		if key != "id" && !fields[key] && !(key == "body" && includeBody) {
+			delete(item, key)
+		}
+	}
+	return item
+}
+
+// etagFromRevision formats a row revision as a strong ETag
+func etagFromRevision(revision int32) string {
+	return `"` + strconv.Itoa(int(revision)) + `"`
+}
+
+// getIfMatchRevision parses the If-Match header into the expected revision.
+// It returns 0 when the header is absent or "*" (no precondition), and false
+// when the header is not a single revision ETag.
+func getIfMatchRevision(r *http.Request) (int32, bool) {
+	h := strings.TrimSpace(r.Header.Get("If-Match"))
+	if h == "" || h == "*" {
+		return 0, true
+	}
+	if len(h) < 3 || h[0] != '"' || h[len(h)-1] != '"' {
+		return 0, false
+	}
+	parsed, err := strconv.Atoi(h[1 : len(h)-1])
+	if err != nil || parsed < 1 {
+		return 0, false
+	}
+	return int32(parsed), true
+}
+
+// respondPreconditionFailed writes a 412 response carrying the resource's current version
+func respondPreconditionFailed(w http.ResponseWriter, revision int32, current interface{}) {
+	w.Header().Set("ETag", etagFromRevision(revision))
+	respondJSON(w, http.StatusPreconditionFailed, map[string]interface{}{
+		"error":   "Resource has been modified since it was fetched",
+		"current": current,
+	})
+}
+
 // nullTimeToPtr converts sql.NullTime to a *time.Time pointer (nil if not valid)
 func nullTimeToPtr(nt sql.NullTime) *time.Time {
 	if nt.Valid {
@@ -66,6 +309,14 @@ func nullTimeToPtr(nt sql.NullTime) *time.Time {
 	return nil
 }
 
+// timePtrToNull converts an optional time to sql.NullTime
+func timePtrToNull(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
 // nullStringToStr converts sql.NullString to a plain string (empty if not valid)
 func nullStringToStr(ns sql.NullString) string {
 	if ns.Valid {
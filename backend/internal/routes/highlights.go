@@ -0,0 +1,270 @@
+package routes
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+const (
+	// maxHighlightLength caps the number of characters a highlight can span
+	maxHighlightLength = 2000
+	// topHighlightsLimit is how many of an article's most highlighted ranges are listed
+	topHighlightsLimit = 5
+)
+
+// HighlightRoutes sets up reader highlight routes on published articles
+func HighlightRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// POST /api/articles/{id}/highlights - Highlight a range of a published article, with an optional private note (auth required)
+	mux.Handle("POST /api/articles/{id}/highlights", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		type request struct {
+			Start int    `json:"start"`
+			End   int    `json:"end"`
+			Quote string `json:"quote"`
+			Note  string `json:"note"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		article, ok := getHighlightableArticle(w, r, dbQueries, articleID)
+		if !ok {
+			return
+		}
+
+		quote, ok := runeRange(article.Body, req.Start, req.End)
+		if !ok || req.Start == req.End {
+			respondError(w, http.StatusBadRequest, "Highlight must be a non-empty range within the article")
+			return
+		}
+		if req.End-req.Start > maxHighlightLength {
+			respondError(w, http.StatusBadRequest, "Highlight must be at most 2000 characters")
+			return
+		}
+		if req.Quote != "" && req.Quote != quote {
+			respondError(w, http.StatusConflict, "Quoted text does not match the article at the given range")
+			return
+		}
+
+		highlight, err := dbQueries.CreateHighlight(r.Context(), database.CreateHighlightParams{
+			ArticleID:   articleID,
+			UserID:      userID,
+			AnchorStart: int32(req.Start),
+			AnchorEnd:   int32(req.End),
+			Quote:       quote,
+			Note:        req.Note,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to create highlight")
+			return
+		}
+
+		respondJSON(w, http.StatusCreated, highlightToResponse(highlight))
+	})))
+
+	// GET /api/articles/{id}/highlights - Top highlights of an article, plus the viewer's own highlights (optional auth)
+	mux.Handle("GET /api/articles/{id}/highlights", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		if _, ok := getHighlightableArticle(w, r, dbQueries, articleID); !ok {
+			return
+		}
+
+		top, err := dbQueries.ListTopHighlights(r.Context(), database.ListTopHighlightsParams{
+			ArticleID: articleID,
+			Limit:     topHighlightsLimit,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch highlights")
+			return
+		}
+
+		topResult := make([]map[string]interface{}, 0, len(top))
+		for _, t := range top {
+			topResult = append(topResult, map[string]interface{}{
+				"start": t.AnchorStart,
+				"end":   t.AnchorEnd,
+				"quote": t.Quote,
+				"count": t.HighlightCount,
+			})
+		}
+
+		own := make([]map[string]interface{}, 0)
+		if userID, ok := middleware.GetUserID(r); ok {
+			highlights, err := dbQueries.ListUserHighlights(r.Context(), database.ListUserHighlightsParams{
+				ArticleID: articleID,
+				UserID:    userID,
+			})
+			if err != nil {
+				respondError(w, http.StatusInternalServerError, "Failed to fetch highlights")
+				return
+			}
+			for _, h := range highlights {
+				own = append(own, highlightToResponse(h))
+			}
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"top":        topResult,
+			"highlights": own,
+			"count":      len(own),
+		})
+	})))
+
+	// PUT /api/highlights/{id} - Change the private note on own highlight (auth required)
+	mux.Handle("PUT /api/highlights/{id}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid highlight ID")
+			return
+		}
+
+		type request struct {
+			Note string `json:"note"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		highlight, err := dbQueries.UpdateHighlightNote(r.Context(), database.UpdateHighlightNoteParams{
+			ID:     id,
+			UserID: userID,
+			Note:   req.Note,
+		})
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Highlight not found")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, highlightToResponse(highlight))
+	})))
+
+	// DELETE /api/highlights/{id} - Delete own highlight (auth required)
+	mux.Handle("DELETE /api/highlights/{id}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid highlight ID")
+			return
+		}
+
+		n, err := dbQueries.DeleteHighlight(r.Context(), database.DeleteHighlightParams{
+			ID:     id,
+			UserID: userID,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to delete highlight")
+			return
+		}
+		if n == 0 {
+			respondError(w, http.StatusNotFound, "Highlight not found")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Highlight deleted successfully"})
+	})))
+}
+
+// getHighlightableArticle loads a published article whose full text the
+// caller can read, writing an error response when there is none
+func getHighlightableArticle(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, articleID uuid.UUID) (database.GetArticleByIDRow, bool) {
+	article, err := dbQueries.GetArticleByID(r.Context(), articleID)
+	if err != nil || article.Status != "published" {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return database.GetArticleByIDRow{}, false
+	}
+
+	access := getReaderAccess(r, dbQueries)
+	if !access.canRead(article.Visibility, article.UserID) {
+		role := ""
+		if access.userID != uuid.Nil {
+			role, _ = articleRole(r.Context(), dbQueries, articleID, access.userID)
+		}
+		if role == "" {
+			respondError(w, http.StatusForbidden, "Highlights of members-only articles are only available to members")
+			return database.GetArticleByIDRow{}, false
+		}
+	}
+	return article, true
+}
+
+// remapHighlightAnchors keeps highlights attached to their quoted text after
+// an article body changes. Highlights are best effort, so failures are only logged.
+func remapHighlightAnchors(ctx context.Context, dbQueries *database.Queries, articleID uuid.UUID, text anchorText) {
+	anchors, err := dbQueries.ListHighlightAnchors(ctx, articleID)
+	if err != nil {
+		log.Printf("Failed to load highlight anchors for article %s: %v", articleID, err)
+		return
+	}
+
+	for _, a := range anchors {
+		start, end, detached, moved := relocateAnchor(text, a.Quote, a.AnchorStart, a.AnchorEnd)
+		if !moved {
+			continue
+		}
+		err := dbQueries.UpdateHighlightAnchor(ctx, database.UpdateHighlightAnchorParams{
+			ID:          a.ID,
+			AnchorStart: start,
+			AnchorEnd:   end,
+			Detached:    detached,
+		})
+		if err != nil {
+			log.Printf("Failed to remap highlight %s: %v", a.ID, err)
+		}
+	}
+}
+
+// highlightToResponse converts a highlight to the JSON response format
+func highlightToResponse(h database.ArticleHighlight) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         h.ID,
+		"article_id": h.ArticleID,
+		"note":       h.Note,
+		"anchor": map[string]interface{}{
+			"start":    h.AnchorStart,
+			"end":      h.AnchorEnd,
+			"quote":    h.Quote,
+			"detached": h.Detached,
+		},
+		"created_at": h.CreatedAt,
+		"updated_at": h.UpdatedAt,
+	}
+}
@@ -0,0 +1,257 @@
+package routes
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+// finishedProgress is how far through an article a reader must get for the
+// progress beacon to mark it finished
+const finishedProgress = 0.9
+
+// HistoryRoutes sets up reading history routes (progress beacon, history, pause)
+func HistoryRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// POST /api/articles/{id}/progress - Progress beacon with the fraction of the article scrolled through (auth required)
+	mux.Handle("POST /api/articles/{id}/progress", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		type request struct {
+			Progress float64 `json:"progress"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.Progress < 0 || req.Progress > 1 {
+			respondError(w, http.StatusBadRequest, "progress must be between 0 and 1")
+			return
+		}
+
+		article, err := dbQueries.GetArticleByID(r.Context(), id)
+		if err != nil || article.Status != "published" {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+
+		n, err := dbQueries.RecordReadingProgress(r.Context(), database.RecordReadingProgressParams{
+			ArticleID:        id,
+			UserID:           userID,
+			Progress:         float32(req.Progress),
+			FinishedProgress: finishedProgress,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to record progress")
+			return
+		}
+
+		// Nothing is recorded while history is paused
+		respondJSON(w, http.StatusOK, map[string]bool{"recorded": n > 0})
+	})))
+
+	// GET /api/users/me/history - Articles opened recently with resume positions (auth required)
+	mux.Handle("GET /api/users/me/history", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		fields, includeBody := getFieldSelection(r)
+
+		paused, err := dbQueries.GetHistoryPaused(r.Context(), userID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch history")
+			return
+		}
+
+		articles, err := dbQueries.ListReadingHistory(r.Context(), database.ListReadingHistoryParams{
+			IncludeBody: includeBody,
+			UserID:      userID,
+			Limit:       limit,
+			Offset:      offset,
+			CursorTime:  cursor.time(),
+			CursorID:    cursor.id(),
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch history")
+			return
+		}
+
+		ids := make([]uuid.UUID, 0, len(articles))
+		for _, a := range articles {
+			ids = append(ids, a.ID)
+		}
+		extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+		bookmarked := getBookmarkedArticles(r, dbQueries, ids)
+		access := getReaderAccess(r, dbQueries)
+
+		result := make([]map[string]interface{}, 0, len(articles))
+		for _, a := range articles {
+			item := articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
+				a.ThumbnailUrl, a.Status, a.PublishedAt, a.CreatedAt, a.UpdatedAt,
+				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, a.ReadingTime,
+				a.PublicationID, a.PublicationName, a.PublicationSlug,
+				extras.tags[a.ID], extras.coAuthors[a.ID])
+			item["comment_count"] = a.CommentCount
+			item["canonical_url"] = a.CanonicalUrl
+			item["language"] = a.Language
+			item["original_published_at"] = nullTimeToPtr(a.OriginalPublishedAt)
+			setBookmarked(item, bookmarked, a.ID)
+			item["reading"] = readingPositionToResponse(a.Progress, a.FinishedAt, a.LastReadAt)
+			item["first_opened_at"] = a.FirstOpenedAt
+			applyVisibility(item, a.Visibility, a.Body, access.canRead(a.Visibility, a.UserID))
+			result = append(result, selectFields(item, fields, includeBody))
+		}
+		var nextCursor *string
+		if len(articles) == int(limit) {
+			last := articles[len(articles)-1]
+			nextCursor = encodeCursor(pageCursor{Time: last.LastReadAt, ID: last.ID})
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"articles":    result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
+			"paused":      paused,
+		})
+	})))
+
+	// DELETE /api/users/me/history - Clear reading history (auth required)
+	mux.Handle("DELETE /api/users/me/history", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		n, err := dbQueries.ClearReadingHistory(r.Context(), userID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to clear history")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"message": "History cleared successfully",
+			"removed": n,
+		})
+	})))
+
+	// DELETE /api/users/me/history/{articleId} - Remove one article from reading history (auth required)
+	mux.Handle("DELETE /api/users/me/history/{articleId}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "articleId")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		n, err := dbQueries.DeleteReadingHistoryItem(r.Context(), database.DeleteReadingHistoryItemParams{
+			UserID:    userID,
+			ArticleID: articleID,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to remove article from history")
+			return
+		}
+		if n == 0 {
+			respondError(w, http.StatusNotFound, "Article is not in your history")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Article removed from history"})
+	})))
+
+	// POST /api/users/me/history/pause - Stop recording reading history (auth required)
+	mux.Handle("POST /api/users/me/history/pause", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setHistoryPaused(w, r, dbQueries, true)
+	})))
+
+	// POST /api/users/me/history/resume - Start recording reading history again (auth required)
+	mux.Handle("POST /api/users/me/history/resume", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setHistoryPaused(w, r, dbQueries, false)
+	})))
+}
+
+// setHistoryPaused handles the pause and resume endpoints
+func setHistoryPaused(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, paused bool) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	err := dbQueries.SetHistoryPaused(r.Context(), database.SetHistoryPausedParams{
+		ID:            userID,
+		HistoryPaused: paused,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update history settings")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]bool{"paused": paused})
+}
+
+// recordArticleOpen adds a published article to the signed-in reader's
+// history. History is best effort, so failures are only logged.
+func recordArticleOpen(r *http.Request, dbQueries *database.Queries, userID, articleID uuid.UUID) {
+	_, err := dbQueries.RecordArticleOpen(r.Context(), database.RecordArticleOpenParams{
+		UserID:    userID,
+		ArticleID: articleID,
+	})
+	if err != nil {
+		log.Printf("Failed to record opening of article %s: %v", articleID, err)
+	}
+}
+
+// getFinishedMode reads the finished query parameter: show lists articles
+// the viewer has finished as usual, last lists them after the rest and hide
+// leaves them out. def applies when the parameter is absent.
+func getFinishedMode(r *http.Request, def string) (string, bool) {
+	mode := r.URL.Query().Get("finished")
+	if mode == "" {
+		mode = def
+	}
+	return mode, mode == "show" || mode == "last" || mode == "hide"
+}
+
+// readingPositionToResponse converts a reader's position in an article to
+// the JSON response format
+func readingPositionToResponse(progress float32, finishedAt sql.NullTime, lastReadAt time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"progress":     progress,
+		"finished":     finishedAt.Valid,
+		"finished_at":  nullTimeToPtr(finishedAt),
+		"last_read_at": lastReadAt,
+	}
+}
@@ -0,0 +1,389 @@
+package routes
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/language"
+	"github.com/jagjeevanak/golang-server/internal/markdown"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+// maxImportSize caps the size of an uploaded Markdown file
+const maxImportSize = 5 << 20
+
+var articleSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ImportExportRoutes sets up Markdown import and export routes
+func ImportExportRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// POST /api/articles/import - Create or update an article from a Markdown file with front matter (auth required)
+	mux.Handle("POST /api/articles/import", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		data, err := readImportFile(w, r, maxImportSize)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid upload: expected a Markdown file")
+			return
+		}
+
+		doc, err := markdown.Parse(data)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid Markdown file: "+err.Error())
+			return
+		}
+
+		article, created, err := importArticle(r.Context(), dbQueries, userID, doc)
+		var ie *importError
+		if errors.As(err, &ie) {
+			respondError(w, ie.status, ie.message)
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to import article: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to import article")
+			return
+		}
+
+		tags, _ := dbQueries.GetArticleTags(r.Context(), article.ID)
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+		w.Header().Set("ETag", etagFromRevision(article.Revision))
+		respondJSON(w, status, articleToResponse(article, tags, "", "", ""))
+	})))
+
+	// GET /api/articles/{id}/export.md - Export an article as Markdown with front matter (collaborators)
+	mux.Handle("GET /api/articles/{id}/export.md", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, id, userID)
+		if err != nil || role == "" {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+
+		article, err := dbQueries.GetArticleByID(r.Context(), id)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+
+		tags, _ := dbQueries.GetArticleTags(r.Context(), article.ID)
+		doc := articleDocument(article.ID, article.Slug, article.Title, article.Body,
+			article.Summary, article.ThumbnailUrl, article.Status, article.Visibility, article.PublishedAt, tags)
+		doc.CanonicalURL = article.CanonicalUrl
+		doc.Language = article.Language
+		doc.OriginalPublishedAt = nullTimeToPtr(article.OriginalPublishedAt)
+		data, err := markdown.Render(doc)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export article")
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, exportFilename(article.ID, article.Slug)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	})))
+
+	// GET /api/users/me/export/articles.zip - Export all own articles as a zip of Markdown files (auth required)
+	mux.Handle("GET /api/users/me/export/articles.zip", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articles, err := dbQueries.ListArticlesForExport(r.Context(), userID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
+			return
+		}
+
+		ids := make([]uuid.UUID, 0, len(articles))
+		for _, a := range articles {
+			ids = append(ids, a.ID)
+		}
+		extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", `attachment; filename="articles.zip"`)
+		w.WriteHeader(http.StatusOK)
+
+		zw := zip.NewWriter(w)
+		for _, a := range articles {
+			doc := articleDocument(a.ID, a.Slug, a.Title, a.Body,
+				a.Summary, a.ThumbnailUrl, a.Status, a.Visibility, a.PublishedAt, extras.tags[a.ID])
+			doc.CanonicalURL = a.CanonicalUrl
+			doc.Language = a.Language
+			doc.OriginalPublishedAt = nullTimeToPtr(a.OriginalPublishedAt)
+			data, err := markdown.Render(doc)
+			if err != nil {
+				log.Printf("Failed to render article %s for export: %v", a.ID, err)
+				continue
+			}
+			f, err := zw.Create(exportFilename(a.ID, a.Slug))
+			if err != nil {
+				log.Printf("Failed to write export archive: %v", err)
+				return
+			}
+			f.Write(data)
+		}
+		if err := zw.Close(); err != nil {
+			log.Printf("Failed to finish export archive: %v", err)
+		}
+	})))
+}
+
+// importError is an import failure that maps to a client error response
+type importError struct {
+	status  int
+	message string
+}
+
+func (e *importError) Error() string {
+	return e.message
+}
+
+// importArticle creates or updates an article from a parsed Markdown document.
+// The front matter ID (or else the slug, among the caller's own articles)
+// selects the article to update; without a match a new article is created.
+func importArticle(ctx context.Context, dbQueries *database.Queries, userID uuid.UUID, doc markdown.Document) (database.Article, bool, error) {
+	doc.Title = strings.TrimSpace(doc.Title)
+	doc.Slug = strings.ToLower(strings.TrimSpace(doc.Slug))
+	if doc.Title == "" || strings.TrimSpace(doc.Body) == "" {
+		return database.Article{}, false, &importError{http.StatusBadRequest, "Title and body are required"}
+	}
+	if msg := validateArticleTitle(doc.Title); msg != "" {
+		return database.Article{}, false, &importError{http.StatusBadRequest, msg}
+	}
+	if doc.Status == "" {
+		doc.Status = "draft"
+	}
+	if doc.Status != "draft" && doc.Status != "published" {
+		return database.Article{}, false, &importError{http.StatusBadRequest, "Status must be 'draft' or 'published'"}
+	}
+	if doc.Visibility == "" {
+		doc.Visibility = "public"
+	}
+	if !isValidVisibility(doc.Visibility) {
+		return database.Article{}, false, &importError{http.StatusBadRequest, "Visibility must be 'public' or 'members'"}
+	}
+	if doc.Slug != "" && (len(doc.Slug) > 255 || !articleSlugPattern.MatchString(doc.Slug)) {
+		return database.Article{}, false, &importError{http.StatusBadRequest, "Slug must be lowercase letters, digits or hyphens"}
+	}
+	if msg := validateCrossPost(doc.CanonicalURL, doc.OriginalPublishedAt); msg != "" {
+		return database.Article{}, false, &importError{http.StatusBadRequest, msg}
+	}
+	if doc.PublishedAt != nil && doc.PublishedAt.After(time.Now()) {
+		return database.Article{}, false, &importError{http.StatusBadRequest, "published_at cannot be in the future"}
+	}
+	if doc.Language == "" {
+		doc.Language = language.Detect(doc.Title + "\n" + doc.Body)
+	}
+	if !language.IsSupported(doc.Language) {
+		return database.Article{}, false, &importError{http.StatusBadRequest, unsupportedLanguageMessage}
+	}
+
+	var existing database.Article
+	role := ""
+	switch {
+	case doc.ID != "":
+		id, err := uuid.Parse(doc.ID)
+		if err != nil {
+			return database.Article{}, false, &importError{http.StatusBadRequest, "Invalid article ID in front matter"}
+		}
+		role, err = articleRole(ctx, dbQueries, id, userID)
+		if err != nil {
+			return database.Article{}, false, &importError{http.StatusNotFound, "Article not found"}
+		}
+		if !canEditArticle(role) {
+			return database.Article{}, false, &importError{http.StatusForbidden, "Not authorized to edit this article"}
+		}
+		row, err := dbQueries.GetArticleByID(ctx, id)
+		if err != nil {
+			return database.Article{}, false, &importError{http.StatusNotFound, "Article not found"}
+		}
+		existing = database.Article{ID: row.ID, Status: row.Status, Slug: row.Slug}
+	case doc.Slug != "":
+		a, err := dbQueries.GetArticleBySlug(ctx, database.GetArticleBySlugParams{
+			UserID: userID,
+			Slug:   sqlNullString(doc.Slug),
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return database.Article{}, false, err
+		}
+		if err == nil {
+			existing, role = a, "owner"
+		}
+	}
+
+	if existing.ID == uuid.Nil {
+		article, err := dbQueries.CreateArticle(ctx, database.CreateArticleParams{
+			UserID:              userID,
+			Title:               doc.Title,
+			Body:                doc.Body,
+			Summary:             doc.Summary,
+			ThumbnailUrl:        doc.Thumbnail,
+			Status:              "draft",
+			Visibility:          doc.Visibility,
+			Slug:                sqlNullString(doc.Slug),
+			CanonicalUrl:        doc.CanonicalURL,
+			OriginalPublishedAt: timePtrToNull(doc.OriginalPublishedAt),
+			Language:            doc.Language,
+		})
+		if err != nil {
+			return database.Article{}, false, err
+		}
+		if doc.Status == "published" {
+			if article, err = dbQueries.PublishArticle(ctx, database.PublishArticleParams{
+				ID:          article.ID,
+				PublishedAt: timePtrToNull(doc.PublishedAt),
+			}); err != nil {
+				return database.Article{}, false, err
+			}
+		}
+		replaceArticleTags(ctx, dbQueries, article.ID, doc.Tags)
+		if article.Status == "published" {
+			invalidateRelatedArticles(ctx, dbQueries, article.ID)
+		}
+		return article, true, nil
+	}
+
+	if existing.Status == "published" && doc.Status == "draft" {
+		return database.Article{}, false, &importError{http.StatusConflict, "Published articles cannot be moved back to draft"}
+	}
+	if existing.Status == "draft" && doc.Status == "published" && !canPublishArticle(role) {
+		return database.Article{}, false, &importError{http.StatusForbidden, "Not authorized to publish this article"}
+	}
+	if existing.Status == "draft" && doc.Status == "published" {
+		inReview, err := dbQueries.HasOpenSubmission(ctx, existing.ID)
+		if err != nil {
+			return database.Article{}, false, err
+		}
+		if inReview {
+			return database.Article{}, false, &importError{http.StatusConflict, underReviewMessage}
+		}
+	}
+
+	if doc.Slug != "" && doc.Slug != existing.Slug.String {
+		err := dbQueries.SetArticleSlug(ctx, database.SetArticleSlugParams{
+			ID:   existing.ID,
+			Slug: sqlNullString(doc.Slug),
+		})
+		if err != nil {
+			return database.Article{}, false, &importError{http.StatusConflict, "Another article already uses this slug"}
+		}
+	}
+
+	article, err := dbQueries.UpdateArticle(ctx, database.UpdateArticleParams{
+		ID:                  existing.ID,
+		Title:               doc.Title,
+		Body:                doc.Body,
+		Summary:             doc.Summary,
+		ThumbnailUrl:        doc.Thumbnail,
+		Visibility:          sqlNullString(doc.Visibility),
+		CanonicalUrl:        sql.NullString{String: doc.CanonicalURL, Valid: true},
+		OriginalPublishedAt: timePtrToNull(doc.OriginalPublishedAt),
+		Language:            sqlNullString(doc.Language),
+	})
+	if isUniqueViolation(err) {
+		return database.Article{}, false, &importError{http.StatusConflict, "Another translation of this article is already in this language"}
+	}
+	if err != nil {
+		return database.Article{}, false, err
+	}
+	if existing.Status == "draft" && doc.Status == "published" {
+		if article, err = dbQueries.PublishArticle(ctx, database.PublishArticleParams{
+			ID:          article.ID,
+			PublishedAt: timePtrToNull(doc.PublishedAt),
+		}); err != nil {
+			return database.Article{}, false, err
+		}
+	}
+
+	replaceArticleTags(ctx, dbQueries, article.ID, doc.Tags)
+	remapAnchorsAsync(dbQueries, article.ID)
+	if existing.Status == "draft" && article.Status == "published" {
+		invalidateRelatedArticles(ctx, dbQueries, article.ID)
+	}
+	return article, false, nil
+}
+
+// readImportFile reads the uploaded file from a multipart "file" field or,
+// for any other content type, from the raw request body
+func readImportFile(w http.ResponseWriter, r *http.Request, maxSize int64) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+	return io.ReadAll(r.Body)
+}
+
+// articleDocument builds the Markdown document for an article
+func articleDocument(id uuid.UUID, slug sql.NullString, title, body, summary, thumbnailUrl, status, visibility string,
+	publishedAt sql.NullTime, tags []database.Tag) markdown.Document {
+	tagNames := make([]string, 0, len(tags))
+	for _, t := range tags {
+		tagNames = append(tagNames, t.Name)
+	}
+
+	doc := markdown.Document{
+		FrontMatter: markdown.FrontMatter{
+			ID:         id.String(),
+			Slug:       slug.String,
+			Title:      title,
+			Summary:    summary,
+			Tags:       tagNames,
+			Status:     status,
+			Thumbnail:  thumbnailUrl,
+			Visibility: visibility,
+		},
+		Body: body,
+	}
+	if publishedAt.Valid {
+		t := publishedAt.Time.UTC()
+		doc.PublishedAt = &t
+	}
+	return doc
+}
+
+// exportFilename names an exported file after the article's slug, falling back to its ID
+func exportFilename(id uuid.UUID, slug sql.NullString) string {
+	if slug.Valid && slug.String != "" {
+		return slug.String + ".md"
+	}
+	return id.String() + ".md"
+}
@@ -0,0 +1,316 @@
+package routes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/importer"
+	"github.com/jagjeevanak/golang-server/internal/language"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+// maxArchiveImportSize caps the size of an uploaded Medium or WordPress export
+const maxArchiveImportSize = 50 << 20
+
+// importReport is the outcome of an import job, or for a dry run the outcome
+// it would have
+type importReport struct {
+	Created int                `json:"created"`
+	Skipped int                `json:"skipped"`
+	Failed  int                `json:"failed"`
+	Posts   []importReportItem `json:"posts"`
+}
+
+// importReportItem is what happened to a single post
+type importReportItem struct {
+	Key       string     `json:"key"`
+	Title     string     `json:"title"`
+	Action    string     `json:"action"`
+	Reason    string     `json:"reason,omitempty"`
+	Status    string     `json:"status,omitempty"`
+	ArticleID *uuid.UUID `json:"article_id,omitempty"`
+}
+
+// ImportRoutes sets up background import routes for Medium and WordPress exports
+func ImportRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// POST /api/imports?source=medium|wordpress&dry_run=true - Start an import job from an export file (auth required)
+	mux.Handle("POST /api/imports", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		source := r.URL.Query().Get("source")
+		if source != "medium" && source != "wordpress" {
+			respondError(w, http.StatusBadRequest, "Source must be 'medium' or 'wordpress'")
+			return
+		}
+		dryRun := r.URL.Query().Get("dry_run") == "true"
+
+		data, err := readImportFile(w, r, maxArchiveImportSize)
+		if err != nil || len(data) == 0 {
+			respondError(w, http.StatusBadRequest, "Invalid upload: expected an export file")
+			return
+		}
+
+		job, err := dbQueries.CreateImportJob(r.Context(), database.CreateImportJobParams{
+			UserID: userID,
+			Source: source,
+			DryRun: dryRun,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to create import job")
+			return
+		}
+
+		// The job outlives the request, so it must not use the request context
+		go runImportJob(context.Background(), dbQueries, job, data)
+
+		respondJSON(w, http.StatusAccepted, importJobToResponse(job))
+	})))
+
+	// GET /api/imports - List own recent import jobs (auth required)
+	mux.Handle("GET /api/imports", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		jobs, err := dbQueries.ListImportJobs(r.Context(), userID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch import jobs")
+			return
+		}
+
+		result := make([]map[string]interface{}, 0, len(jobs))
+		for _, j := range jobs {
+			result = append(result, importJobToResponse(j))
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"imports": result,
+			"count":   len(result),
+		})
+	})))
+
+	// GET /api/imports/{id} - Get an import job's status and report (auth required)
+	mux.Handle("GET /api/imports/{id}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid import ID")
+			return
+		}
+
+		job, err := dbQueries.GetImportJob(r.Context(), database.GetImportJobParams{
+			ID:     id,
+			UserID: userID,
+		})
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Import not found")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, importJobToResponse(job))
+	})))
+}
+
+// FailInterruptedImports marks import jobs left unfinished by a previous run
+// of the server as failed, so they do not stay running forever. It must run
+// before the server accepts new imports.
+func FailInterruptedImports(ctx context.Context, dbQueries *database.Queries) {
+	n, err := dbQueries.FailInterruptedImportJobs(ctx)
+	if err != nil {
+		log.Printf("Failed to mark interrupted imports as failed: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("Marked %d interrupted import(s) as failed", n)
+	}
+}
+
+// runImportJob parses an export file and imports its posts, recording the
+// outcome of each post in the job's report
+func runImportJob(ctx context.Context, dbQueries *database.Queries, job database.ImportJob, data []byte) {
+	finish := func(status string, report importReport, jobErr string) {
+		raw, err := json.Marshal(report)
+		if err != nil {
+			raw = []byte("{}")
+		}
+		err = dbQueries.FinishImportJob(ctx, database.FinishImportJobParams{
+			ID:     job.ID,
+			Status: status,
+			Report: raw,
+			Error:  jobErr,
+		})
+		if err != nil {
+			log.Printf("Failed to finish import job %s: %v", job.ID, err)
+		}
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			log.Printf("Import job %s panicked: %v", job.ID, p)
+			finish("failed", importReport{}, "Internal error while importing")
+		}
+	}()
+
+	if err := dbQueries.StartImportJob(ctx, job.ID); err != nil {
+		log.Printf("Failed to start import job %s: %v", job.ID, err)
+	}
+
+	var posts []importer.Post
+	var err error
+	switch job.Source {
+	case "medium":
+		posts, err = importer.ParseMediumExport(data)
+	case "wordpress":
+		posts, err = importer.ParseWordPressExport(data)
+	default:
+		err = fmt.Errorf("unknown source %q", job.Source)
+	}
+	if err != nil {
+		finish("failed", importReport{}, err.Error())
+		return
+	}
+
+	keys := make([]string, 0, len(posts))
+	for _, p := range posts {
+		keys = append(keys, p.Key)
+	}
+	imported, err := dbQueries.ListImportKeys(ctx, database.ListImportKeysParams{
+		UserID:     job.UserID,
+		ImportKeys: keys,
+	})
+	if err != nil {
+		finish("failed", importReport{}, "Failed to check for previously imported posts")
+		return
+	}
+	seen := make(map[string]bool, len(posts))
+	for _, k := range imported {
+		seen[k] = true
+	}
+
+	report := importReport{Posts: make([]importReportItem, 0, len(posts))}
+	for _, p := range posts {
+		item := importPost(ctx, dbQueries, job, p, seen)
+		seen[p.Key] = true
+
+		switch item.Action {
+		case "create":
+			report.Created++
+		case "skip":
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+		report.Posts = append(report.Posts, item)
+	}
+
+	finish("completed", report, "")
+}
+
+// importPost imports a single post, or for a dry run only checks that it
+// would be imported. seen holds the keys of posts already imported.
+func importPost(ctx context.Context, dbQueries *database.Queries, job database.ImportJob, p importer.Post, seen map[string]bool) importReportItem {
+	item := importReportItem{Key: p.Key, Title: p.Title, Status: p.Status}
+	title := strings.TrimSpace(p.Title)
+
+	switch {
+	case p.Skip != "":
+		item.Action, item.Reason = "skip", p.Skip
+	case seen[p.Key]:
+		item.Action, item.Reason = "skip", "already imported"
+	case title == "":
+		item.Action, item.Reason = "fail", "missing title"
+	case utf8.RuneCountInString(title) > 255:
+		item.Action, item.Reason = "fail", "title is longer than 255 characters"
+	case strings.TrimSpace(p.Body) == "":
+		item.Action, item.Reason = "fail", "missing body"
+	default:
+		item.Action = "create"
+	}
+	if item.Action != "create" || job.DryRun {
+		return item
+	}
+
+	var publishedAt sql.NullTime
+	if p.Status == "published" {
+		publishedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+		if p.PublishedAt != nil {
+			publishedAt.Time = p.PublishedAt.UTC()
+		}
+	}
+
+	// A malformed source link is dropped rather than failing the post
+	canonicalUrl := p.CanonicalURL
+	if !isAbsoluteHTTPURL(canonicalUrl) {
+		canonicalUrl = ""
+	}
+
+	article, err := dbQueries.CreateImportedArticle(ctx, database.CreateImportedArticleParams{
+		UserID:              job.UserID,
+		Title:               title,
+		Body:                p.Body,
+		Summary:             p.Summary,
+		Status:              p.Status,
+		PublishedAt:         publishedAt,
+		ImportKey:           sqlNullString(p.Key),
+		CanonicalUrl:        canonicalUrl,
+		OriginalPublishedAt: timePtrToNull(p.PublishedAt),
+		Language:            language.Detect(title + "\n" + p.Body),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		item.Action, item.Reason = "skip", "already imported"
+		return item
+	}
+	if err != nil {
+		log.Printf("Import job %s failed to create %s: %v", job.ID, p.Key, err)
+		item.Action, item.Reason = "fail", "could not be saved"
+		return item
+	}
+
+	replaceArticleTags(ctx, dbQueries, article.ID, p.Tags)
+	if article.Status == "published" {
+		invalidateRelatedArticles(ctx, dbQueries, article.ID)
+	}
+	item.ArticleID = &article.ID
+	return item
+}
+
+// importJobToResponse converts an import job to the JSON response format
+func importJobToResponse(j database.ImportJob) map[string]interface{} {
+	resp := map[string]interface{}{
+		"id":          j.ID,
+		"source":      j.Source,
+		"dry_run":     j.DryRun,
+		"status":      j.Status,
+		"created_at":  j.CreatedAt,
+		"finished_at": nullTimeToPtr(j.FinishedAt),
+	}
+	if j.Error != "" {
+		resp["error"] = j.Error
+	}
+	if j.Status == "completed" {
+		resp["report"] = j.Report
+	}
+	return resp
+}
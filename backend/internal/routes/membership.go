@@ -0,0 +1,70 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+// previewParagraphs is how much of a members-only article non-members can read
+const previewParagraphs = 3
+
+// readerAccess describes which members-only articles the caller may read in full
+type readerAccess struct {
+	userID uuid.UUID
+	member bool
+}
+
+// getReaderAccess looks up the caller's membership. Anonymous callers get the
+// zero value, which can only read public articles.
+func getReaderAccess(r *http.Request, dbQueries *database.Queries) readerAccess {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		return readerAccess{}
+	}
+
+	user, err := dbQueries.GetUserByID(r.Context(), userID)
+	if err != nil {
+		return readerAccess{userID: userID}
+	}
+	return readerAccess{userID: userID, member: user.IsMember || user.IsAdmin}
+}
+
+// canRead reports whether the caller may read an article in full. Authors
+// can always read their own articles.
+func (a readerAccess) canRead(visibility string, authorID uuid.UUID) bool {
+	return visibility != "members" || a.member || (a.userID != uuid.Nil && a.userID == authorID)
+}
+
+// isValidVisibility reports whether v is an article visibility
+func isValidVisibility(v string) bool {
+	return v == "public" || v == "members"
+}
+
+// applyVisibility adds the visibility and locked fields to an article response,
+// swapping the body for a preview when the caller cannot read it in full
+func applyVisibility(item map[string]interface{}, visibility, body string, canRead bool) {
+	item["visibility"] = visibility
+	item["locked"] = !canRead
+	if !canRead {
+		item["body"] = articlePreview(body)
+	}
+}
+
+// articlePreview returns the first previewParagraphs paragraphs of a body
+func articlePreview(body string) string {
+	paragraphs := make([]string, 0, previewParagraphs)
+	for _, p := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		paragraphs = append(paragraphs, p)
+		if len(paragraphs) == previewParagraphs {
+			break
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
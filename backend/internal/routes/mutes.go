@@ -0,0 +1,294 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+const (
+	// maxMutedKeywordLength caps the number of characters in a muted keyword
+	maxMutedKeywordLength = 100
+	// maxMutedKeywords caps how many keywords a reader can mute
+	maxMutedKeywords = 100
+)
+
+// MuteRoutes sets up routes for muting authors, tags and keywords. Mutes are
+// applied by the listing, feed, tag and search queries themselves.
+func MuteRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// GET /api/users/me/mutes - Own muted authors, tags and keywords (auth required)
+	mux.Handle("GET /api/users/me/mutes", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		respondMutes(w, r, dbQueries, userID)
+	})))
+
+	// POST /api/users/me/mutes/authors - Mute an author (auth required)
+	mux.Handle("POST /api/users/me/mutes/authors", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		type request struct {
+			Username string `json:"username"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil || req.Username == "" {
+			respondError(w, http.StatusBadRequest, "A 'username' to mute is required")
+			return
+		}
+
+		author, err := dbQueries.GetUserByUsername(r.Context(), sqlNullString(req.Username))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		if author.ID == userID {
+			respondError(w, http.StatusBadRequest, "You cannot mute yourself")
+			return
+		}
+
+		err = dbQueries.MuteAuthor(r.Context(), database.MuteAuthorParams{
+			UserID:      userID,
+			MutedUserID: author.ID,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to mute author")
+			return
+		}
+
+		respondMutes(w, r, dbQueries, userID)
+	})))
+
+	// DELETE /api/users/me/mutes/authors/{username} - Unmute an author (auth required)
+	mux.Handle("DELETE /api/users/me/mutes/authors/{username}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		author, err := dbQueries.GetUserByUsername(r.Context(), sqlNullString(r.PathValue("username")))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "User not found")
+			return
+		}
+
+		n, err := dbQueries.UnmuteAuthor(r.Context(), database.UnmuteAuthorParams{
+			UserID:      userID,
+			MutedUserID: author.ID,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to unmute author")
+			return
+		}
+		if n == 0 {
+			respondError(w, http.StatusNotFound, "Author is not muted")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Author unmuted successfully"})
+	})))
+
+	// POST /api/users/me/mutes/tags - Mute a tag (auth required)
+	mux.Handle("POST /api/users/me/mutes/tags", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		type request struct {
+			Tag string `json:"tag"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil || req.Tag == "" {
+			respondError(w, http.StatusBadRequest, "A 'tag' to mute is required")
+			return
+		}
+
+		tag, err := dbQueries.GetTagByName(r.Context(), req.Tag)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Tag not found")
+			return
+		}
+
+		err = dbQueries.MuteTag(r.Context(), database.MuteTagParams{
+			UserID: userID,
+			TagID:  tag.ID,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to mute tag")
+			return
+		}
+
+		respondMutes(w, r, dbQueries, userID)
+	})))
+
+	// DELETE /api/users/me/mutes/tags/{name} - Unmute a tag (auth required)
+	mux.Handle("DELETE /api/users/me/mutes/tags/{name}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		n, err := dbQueries.UnmuteTag(r.Context(), database.UnmuteTagParams{
+			UserID:  userID,
+			TagName: r.PathValue("name"),
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to unmute tag")
+			return
+		}
+		if n == 0 {
+			respondError(w, http.StatusNotFound, "Tag is not muted")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Tag unmuted successfully"})
+	})))
+
+	// POST /api/users/me/mutes/keywords - Mute a keyword in article titles and summaries (auth required)
+	mux.Handle("POST /api/users/me/mutes/keywords", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		type request struct {
+			Keyword string `json:"keyword"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		keyword := strings.TrimSpace(req.Keyword)
+		if keyword == "" || utf8.RuneCountInString(keyword) > maxMutedKeywordLength {
+			respondError(w, http.StatusBadRequest, "Keyword must be between 1 and 100 characters")
+			return
+		}
+
+		count, err := dbQueries.CountMutedKeywords(r.Context(), userID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to mute keyword")
+			return
+		}
+		if count >= maxMutedKeywords {
+			respondError(w, http.StatusBadRequest, "You can mute at most 100 keywords")
+			return
+		}
+
+		err = dbQueries.MuteKeyword(r.Context(), database.MuteKeywordParams{
+			UserID:  userID,
+			Keyword: keyword,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to mute keyword")
+			return
+		}
+
+		respondMutes(w, r, dbQueries, userID)
+	})))
+
+	// DELETE /api/users/me/mutes/keywords/{keyword} - Unmute a keyword (auth required)
+	mux.Handle("DELETE /api/users/me/mutes/keywords/{keyword}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		n, err := dbQueries.UnmuteKeyword(r.Context(), database.UnmuteKeywordParams{
+			UserID:  userID,
+			Keyword: strings.TrimSpace(r.PathValue("keyword")),
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to unmute keyword")
+			return
+		}
+		if n == 0 {
+			respondError(w, http.StatusNotFound, "Keyword is not muted")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Keyword unmuted successfully"})
+	})))
+}
+
+// respondMutes writes a reader's muted authors, tags and keywords
+func respondMutes(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, userID uuid.UUID) {
+	authors, err := dbQueries.ListMutedAuthors(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch mutes")
+		return
+	}
+	tags, err := dbQueries.ListMutedTags(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch mutes")
+		return
+	}
+	keywords, err := dbQueries.ListMutedKeywords(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch mutes")
+		return
+	}
+
+	authorResult := make([]map[string]interface{}, 0, len(authors))
+	for _, a := range authors {
+		authorResult = append(authorResult, map[string]interface{}{
+			"id":         a.ID,
+			"username":   nullStringToStr(a.Username),
+			"name":       a.Name,
+			"avatar_url": a.AvatarUrl,
+			"muted_at":   a.MutedAt,
+		})
+	}
+	tagResult := make([]map[string]interface{}, 0, len(tags))
+	for _, t := range tags {
+		tagResult = append(tagResult, map[string]interface{}{
+			"name":     t.Name,
+			"muted_at": t.MutedAt,
+		})
+	}
+	keywordResult := make([]map[string]interface{}, 0, len(keywords))
+	for _, k := range keywords {
+		keywordResult = append(keywordResult, map[string]interface{}{
+			"keyword":  k.Keyword,
+			"muted_at": k.MutedAt,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"authors":  authorResult,
+		"tags":     tagResult,
+		"keywords": keywordResult,
+	})
+}
+
+// getViewerID returns the signed-in caller's ID, or NULL for anonymous
+// callers, for queries that tailor results to the viewer
+func getViewerID(r *http.Request) uuid.NullUUID {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: userID, Valid: true}
+}
@@ -0,0 +1,403 @@
+package routes
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+// NoteRoutes sets up private reviewer note routes on drafts. Callers are either
+// collaborators (bearer token) or share link reviewers (?share= token).
+func NoteRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// POST /api/articles/{id}/notes - Add a note anchored to a range of a draft (collaborators or share link)
+	mux.Handle("POST /api/articles/{id}/notes", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		type request struct {
+			Body  string `json:"body"`
+			Start int    `json:"start"`
+			End   int    `json:"end"`
+			Quote string `json:"quote"`
+			Name  string `json:"name"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if strings.TrimSpace(req.Body) == "" {
+			respondError(w, http.StatusBadRequest, "Note body is required")
+			return
+		}
+
+		reviewer, ok := getNoteReviewer(r, dbQueries, cfg, articleID)
+		if !ok {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+
+		article, err := dbQueries.GetArticleByID(r.Context(), articleID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if article.Status != "draft" {
+			respondError(w, http.StatusConflict, "Notes can only be added to drafts")
+			return
+		}
+
+		quote, ok := runeRange(article.Body, req.Start, req.End)
+		if !ok || req.Start == req.End {
+			respondError(w, http.StatusBadRequest, "Anchor must be a non-empty range within the draft")
+			return
+		}
+		if req.Quote != "" && req.Quote != quote {
+			respondError(w, http.StatusConflict, "Quoted text does not match the draft at the given range")
+			return
+		}
+
+		var authorUsername, authorName sql.NullString
+		reviewerName := ""
+		if reviewer.userID.Valid {
+			if user, err := dbQueries.GetUserByID(r.Context(), reviewer.userID.UUID); err == nil {
+				authorUsername = user.Username
+				authorName = sql.NullString{String: user.Name, Valid: true}
+			}
+		} else {
+			reviewerName = strings.TrimSpace(req.Name)
+			if reviewerName == "" {
+				reviewerName = "Reviewer"
+			}
+		}
+
+		note, err := dbQueries.CreateArticleNote(r.Context(), database.CreateArticleNoteParams{
+			ArticleID:    articleID,
+			UserID:       reviewer.userID,
+			ShareLinkID:  reviewer.shareLinkID,
+			ReviewerName: reviewerName,
+			Body:         req.Body,
+			AnchorStart:  int32(req.Start),
+			AnchorEnd:    int32(req.End),
+			Quote:        quote,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to create note")
+			return
+		}
+
+		respondJSON(w, http.StatusCreated, noteToResponse(note, authorUsername, authorName))
+	})))
+
+	// GET /api/articles/{id}/notes - List notes on an article (collaborators or share link)
+	mux.Handle("GET /api/articles/{id}/notes", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		if _, ok := getNoteReviewer(r, dbQueries, cfg, articleID); !ok {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+
+		notes, err := dbQueries.ListArticleNotes(r.Context(), articleID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch notes")
+			return
+		}
+
+		result := make([]map[string]interface{}, 0, len(notes))
+		for _, n := range notes {
+			result = append(result, noteToResponse(n.ArticleNote, n.AuthorUsername, n.AuthorName))
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"notes": result,
+			"count": len(result),
+		})
+	})))
+
+	// POST /api/notes/{id}/resolve - Mark a note as resolved (collaborators or share link)
+	mux.Handle("POST /api/notes/{id}/resolve", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setNoteResolved(w, r, dbQueries, cfg, true)
+	})))
+
+	// POST /api/notes/{id}/unresolve - Reopen a resolved note (collaborators or share link)
+	mux.Handle("POST /api/notes/{id}/unresolve", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setNoteResolved(w, r, dbQueries, cfg, false)
+	})))
+
+	// DELETE /api/notes/{id} - Delete a note (its writer or the article owner)
+	mux.Handle("DELETE /api/notes/{id}", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		noteID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid note ID")
+			return
+		}
+
+		existing, err := dbQueries.GetArticleNote(r.Context(), noteID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Note not found")
+			return
+		}
+		note := existing.ArticleNote
+
+		reviewer, ok := getNoteReviewer(r, dbQueries, cfg, note.ArticleID)
+		if !ok {
+			respondError(w, http.StatusNotFound, "Note not found")
+			return
+		}
+
+		isWriter := (reviewer.userID.Valid && reviewer.userID == note.UserID) ||
+			(reviewer.shareLinkID.Valid && reviewer.shareLinkID == note.ShareLinkID)
+		if !isWriter && reviewer.role != "owner" {
+			respondError(w, http.StatusForbidden, "Not authorized to delete this note")
+			return
+		}
+
+		if err := dbQueries.DeleteArticleNote(r.Context(), noteID); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to delete note")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Note deleted successfully"})
+	})))
+}
+
+// setNoteResolved handles the resolve and unresolve endpoints
+func setNoteResolved(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, cfg *config.ApiConfig, resolved bool) {
+	noteID, err := getPathID(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	existing, err := dbQueries.GetArticleNote(r.Context(), noteID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Note not found")
+		return
+	}
+
+	if _, ok := getNoteReviewer(r, dbQueries, cfg, existing.ArticleNote.ArticleID); !ok {
+		respondError(w, http.StatusNotFound, "Note not found")
+		return
+	}
+
+	note, err := dbQueries.SetArticleNoteResolved(r.Context(), database.SetArticleNoteResolvedParams{
+		ID:       noteID,
+		Resolved: resolved,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update note")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, noteToResponse(note, existing.AuthorUsername, existing.AuthorName))
+}
+
+// noteReviewer identifies who is reading or writing notes: a collaborator
+// account or a share link
+type noteReviewer struct {
+	userID      uuid.NullUUID
+	shareLinkID uuid.NullUUID
+	role        string
+}
+
+// getNoteReviewer resolves the caller's access to an article's notes. The
+// author and collaborators have access through their account, reviewers
+// through a share link for the article. It returns false when neither applies.
+func getNoteReviewer(r *http.Request, dbQueries *database.Queries, cfg *config.ApiConfig, articleID uuid.UUID) (noteReviewer, bool) {
+	if userID, ok := middleware.GetUserID(r); ok {
+		role, err := articleRole(r.Context(), dbQueries, articleID, userID)
+		if err == nil && role != "" {
+			return noteReviewer{userID: uuid.NullUUID{UUID: userID, Valid: true}, role: role}, true
+		}
+	}
+
+	if link, ok := getShareLink(r, dbQueries, cfg, articleID); ok {
+		return noteReviewer{shareLinkID: uuid.NullUUID{UUID: link.ID, Valid: true}}, true
+	}
+	return noteReviewer{}, false
+}
+
+// noteToResponse converts a note to the JSON response format
+func noteToResponse(n database.ArticleNote, authorUsername, authorName sql.NullString) map[string]interface{} {
+	author := map[string]interface{}{"name": n.ReviewerName}
+	if n.UserID.Valid {
+		author = map[string]interface{}{
+			"username": nullStringToStr(authorUsername),
+			"name":     authorName.String,
+		}
+	}
+
+	return map[string]interface{}{
+		"id":         n.ID,
+		"article_id": n.ArticleID,
+		"body":       n.Body,
+		"anchor": map[string]interface{}{
+			"start":    n.AnchorStart,
+			"end":      n.AnchorEnd,
+			"quote":    n.Quote,
+			"detached": n.Detached,
+		},
+		"author":      author,
+		"resolved":    n.ResolvedAt.Valid,
+		"resolved_at": nullTimeToPtr(n.ResolvedAt),
+		"created_at":  n.CreatedAt,
+		"updated_at":  n.UpdatedAt,
+	}
+}
+
+// runeRange returns the text between character offsets start and end of s
+func runeRange(s string, start, end int) (string, bool) {
+	runes := []rune(s)
+	if start < 0 || end < start || end > len(runes) {
+		return "", false
+	}
+	return string(runes[start:end]), true
+}
+
+// anchorRemapLocks serializes anchor remaps per article (striped by ID), so a
+// remap for an older edit can't finish after one for a newer edit
+var anchorRemapLocks [64]sync.Mutex
+
+// remapAnchorsAsync keeps note and highlight anchors attached to their quoted
+// text after an article body changes, without holding up the response. The
+// remap reads the article's latest body when it runs.
+func remapAnchorsAsync(dbQueries *database.Queries, articleID uuid.UUID) {
+	go func() {
+		lock := &anchorRemapLocks[articleID[0]%byte(len(anchorRemapLocks))]
+		lock.Lock()
+		defer lock.Unlock()
+
+		ctx := context.Background()
+		article, err := dbQueries.GetArticleByID(ctx, articleID)
+		if err != nil {
+			log.Printf("Failed to load article %s to remap anchors: %v", articleID, err)
+			return
+		}
+		text := newAnchorText(article.Body)
+		remapNoteAnchors(ctx, dbQueries, articleID, text)
+		remapHighlightAnchors(ctx, dbQueries, articleID, text)
+	}()
+}
+
+// remapNoteAnchors keeps note anchors attached to their quoted text after an
+// article body changes. Anchors are best effort, so failures are only logged.
+func remapNoteAnchors(ctx context.Context, dbQueries *database.Queries, articleID uuid.UUID, text anchorText) {
+	anchors, err := dbQueries.ListNoteAnchors(ctx, articleID)
+	if err != nil {
+		log.Printf("Failed to load note anchors for article %s: %v", articleID, err)
+		return
+	}
+
+	for _, a := range anchors {
+		start, end, detached, moved := relocateAnchor(text, a.Quote, a.AnchorStart, a.AnchorEnd)
+		if !moved {
+			continue
+		}
+		err := dbQueries.UpdateNoteAnchor(ctx, database.UpdateNoteAnchorParams{
+			ID:          a.ID,
+			AnchorStart: start,
+			AnchorEnd:   end,
+			Detached:    detached,
+		})
+		if err != nil {
+			log.Printf("Failed to remap note %s: %v", a.ID, err)
+		}
+	}
+}
+
+// anchorText is an article body with the byte offset of every character, so
+// character-offset anchors can be checked and searched without copying text
+type anchorText struct {
+	body string
+	// offsets[i] is the byte offset of character i; the last entry is len(body)
+	offsets []int
+}
+
+func newAnchorText(body string) anchorText {
+	offsets := make([]int, 0, len(body)+1)
+	for i := range body {
+		offsets = append(offsets, i)
+	}
+	return anchorText{body: body, offsets: append(offsets, len(body))}
+}
+
+// slice returns the text between character offsets start and end
+func (t anchorText) slice(start, end int) (string, bool) {
+	if start < 0 || end < start || end >= len(t.offsets) {
+		return "", false
+	}
+	return t.body[t.offsets[start]:t.offsets[end]], true
+}
+
+// charOffset converts a byte offset at a character boundary to a character offset
+func (t anchorText) charOffset(byteOffset int) int {
+	return sort.SearchInts(t.offsets, byteOffset)
+}
+
+// relocateAnchor finds an anchored quote in a changed text. An anchor stays
+// put if its quote is still there, moves to the nearest occurrence of the
+// quote otherwise, and is detached when the quote no longer appears. moved
+// reports whether the anchor has to be updated.
+func relocateAnchor(text anchorText, quote string, start, end int32) (newStart, newEnd int32, detached, moved bool) {
+	length := utf8.RuneCountInString(quote)
+	at := int(start)
+	if current, ok := text.slice(at, at+length); ok && current == quote {
+		return start, end, false, false
+	}
+
+	if pos := nearestOccurrence(text, quote, at); pos >= 0 {
+		return int32(pos), int32(pos + length), false, true
+	}
+	return start, end, true, true
+}
+
+// nearestOccurrence returns the character offset of the occurrence of quote
+// in text that is closest to near, or -1 when quote does not occur
+func nearestOccurrence(text anchorText, quote string, near int) int {
+	best := -1
+	if quote == "" {
+		return best
+	}
+	for from := 0; from < len(text.body); {
+		i := strings.Index(text.body[from:], quote)
+		if i < 0 {
+			break
+		}
+		pos := text.charOffset(from + i)
+		if best < 0 || abs(pos-near) < abs(best-near) {
+			best = pos
+		} else if pos > near {
+			// Later occurrences are only further away
+			break
+		}
+		from += i + 1
+	}
+	return best
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,744 @@
+package routes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+var publicationSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// PublicationRoutes sets up publication routes (outlets, members, follows, submissions)
+func PublicationRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// POST /api/publications - Create a publication, creator becomes owner (auth required)
+	mux.Handle("POST /api/publications", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		type request struct {
+			Slug        string `json:"slug"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			AvatarUrl   string `json:"avatar_url"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		req.Slug = strings.ToLower(req.Slug)
+		if req.Name == "" || req.Slug == "" {
+			respondError(w, http.StatusBadRequest, "Name and slug are required")
+			return
+		}
+		if len(req.Slug) < 3 || len(req.Slug) > 60 || !publicationSlugPattern.MatchString(req.Slug) {
+			respondError(w, http.StatusBadRequest, "Slug must be 3-60 lowercase letters, digits or hyphens")
+			return
+		}
+
+		publication, err := dbQueries.CreatePublication(r.Context(), database.CreatePublicationParams{
+			Slug:        req.Slug,
+			Name:        req.Name,
+			Description: req.Description,
+			AvatarUrl:   req.AvatarUrl,
+			OwnerID:     userID,
+		})
+		if isUniqueViolation(err) {
+			respondError(w, http.StatusConflict, "Publication with this slug already exists")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to create publication")
+			return
+		}
+
+		respondJSON(w, http.StatusCreated, publicationToResponse(database.Publication(publication)))
+	})))
+
+	// GET /api/publications/{slug} - Get publication with members and follower count
+	mux.HandleFunc("GET /api/publications/{slug}", func(w http.ResponseWriter, r *http.Request) {
+		publication, err := dbQueries.GetPublicationBySlug(r.Context(), r.PathValue("slug"))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Publication not found")
+			return
+		}
+
+		members, _ := dbQueries.ListPublicationMembers(r.Context(), publication.ID)
+		followerCount, _ := dbQueries.CountPublicationFollowers(r.Context(), publication.ID)
+
+		memberList := make([]map[string]interface{}, 0, len(members))
+		for _, m := range members {
+			memberList = append(memberList, map[string]interface{}{
+				"id":         m.ID,
+				"username":   nullStringToStr(m.Username),
+				"name":       m.Name,
+				"avatar_url": m.AvatarUrl,
+				"role":       m.Role,
+			})
+		}
+
+		resp := publicationToResponse(publication)
+		resp["members"] = memberList
+		resp["follower_count"] = followerCount
+
+		respondJSON(w, http.StatusOK, resp)
+	})
+
+	// PUT /api/publications/{slug} - Update publication details (owner only)
+	mux.Handle("PUT /api/publications/{slug}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		publication, err := dbQueries.GetPublicationBySlug(r.Context(), r.PathValue("slug"))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Publication not found")
+			return
+		}
+
+		if publicationRole(r.Context(), dbQueries, publication.ID, userID) != "owner" {
+			respondError(w, http.StatusForbidden, "Only the publication owner can update it")
+			return
+		}
+
+		type request struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			AvatarUrl   string `json:"avatar_url"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.Name == "" {
+			respondError(w, http.StatusBadRequest, "Name is required")
+			return
+		}
+
+		publication, err = dbQueries.UpdatePublication(r.Context(), database.UpdatePublicationParams{
+			ID:          publication.ID,
+			Name:        req.Name,
+			Description: req.Description,
+			AvatarUrl:   req.AvatarUrl,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to update publication")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, publicationToResponse(publication))
+	})))
+
+	// POST /api/publications/{slug}/members - Add or change a member's role (owner only)
+	mux.Handle("POST /api/publications/{slug}/members", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		publication, err := dbQueries.GetPublicationBySlug(r.Context(), r.PathValue("slug"))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Publication not found")
+			return
+		}
+
+		if publicationRole(r.Context(), dbQueries, publication.ID, userID) != "owner" {
+			respondError(w, http.StatusForbidden, "Only the publication owner can manage members")
+			return
+		}
+
+		type request struct {
+			Username string `json:"username"`
+			Role     string `json:"role"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.Role != "editor" && req.Role != "writer" {
+			respondError(w, http.StatusBadRequest, "Role must be 'editor' or 'writer'")
+			return
+		}
+
+		member, err := dbQueries.GetUserByUsername(r.Context(), sqlNullString(req.Username))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "User not found")
+			return
+		}
+
+		if member.ID == userID {
+			respondError(w, http.StatusBadRequest, "Cannot change your own role")
+			return
+		}
+
+		err = dbQueries.AddPublicationMember(r.Context(), database.AddPublicationMemberParams{
+			PublicationID: publication.ID,
+			UserID:        member.ID,
+			Role:          req.Role,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to add member")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"id":       member.ID,
+			"username": nullStringToStr(member.Username),
+			"role":     req.Role,
+		})
+	})))
+
+	// DELETE /api/publications/{slug}/members/{username} - Remove a member (owner) or leave (self)
+	mux.Handle("DELETE /api/publications/{slug}/members/{username}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		publication, err := dbQueries.GetPublicationBySlug(r.Context(), r.PathValue("slug"))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Publication not found")
+			return
+		}
+
+		member, err := dbQueries.GetUserByUsername(r.Context(), sqlNullString(r.PathValue("username")))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "User not found")
+			return
+		}
+
+		if publicationRole(r.Context(), dbQueries, publication.ID, member.ID) == "owner" {
+			respondError(w, http.StatusBadRequest, "The publication owner cannot be removed")
+			return
+		}
+		if member.ID != userID && publicationRole(r.Context(), dbQueries, publication.ID, userID) != "owner" {
+			respondError(w, http.StatusForbidden, "Only the publication owner can remove members")
+			return
+		}
+
+		err = dbQueries.RemovePublicationMember(r.Context(), database.RemovePublicationMemberParams{
+			PublicationID: publication.ID,
+			UserID:        member.ID,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to remove member")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Member removed successfully"})
+	})))
+
+	// GET /api/publications/{slug}/articles - List articles published under a publication
+	mux.Handle("GET /api/publications/{slug}/articles", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		publication, err := dbQueries.GetPublicationBySlug(r.Context(), r.PathValue("slug"))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Publication not found")
+			return
+		}
+
+		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		fields, includeBody := getFieldSelection(r)
+
+		articles, err := dbQueries.ListArticlesByPublication(r.Context(), database.ListArticlesByPublicationParams{
+			IncludeBody:   includeBody,
+			PublicationID: uuid.NullUUID{UUID: publication.ID, Valid: true},
+			Limit:         limit,
+			Offset:        offset,
+			CursorTime:    cursor.time(),
+			CursorID:      cursor.id(),
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
+			return
+		}
+
+		ids := make([]uuid.UUID, 0, len(articles))
+		for _, a := range articles {
+			ids = append(ids, a.ID)
+		}
+		extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+		bookmarked := getBookmarkedArticles(r, dbQueries, ids)
+		access := getReaderAccess(r, dbQueries)
+
+		result := make([]map[string]interface{}, 0, len(articles))
+		for _, a := range articles {
+			item := articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
+				a.ThumbnailUrl, a.Status, a.PublishedAt, a.CreatedAt, a.UpdatedAt,
+				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, a.ReadingTime,
+				a.PublicationID, a.PublicationName, a.PublicationSlug,
+				extras.tags[a.ID], extras.coAuthors[a.ID])
+			item["comment_count"] = a.CommentCount
+			item["canonical_url"] = a.CanonicalUrl
+			item["language"] = a.Language
+			item["original_published_at"] = nullTimeToPtr(a.OriginalPublishedAt)
+			setBookmarked(item, bookmarked, a.ID)
+			applyVisibility(item, a.Visibility, a.Body, access.canRead(a.Visibility, a.UserID))
+			result = append(result, selectFields(item, fields, includeBody))
+		}
+		var nextCursor *string
+		if len(articles) == int(limit) {
+			last := articles[len(articles)-1]
+			nextCursor = encodeCursor(pageCursor{Time: last.PublishedAt.Time, ID: last.ID})
+		}
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"articles":    result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
+		})
+	})))
+
+	// POST /api/publications/{slug}/follow - Follow a publication (auth required)
+	mux.Handle("POST /api/publications/{slug}/follow", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		publication, err := dbQueries.GetPublicationBySlug(r.Context(), r.PathValue("slug"))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Publication not found")
+			return
+		}
+
+		err = dbQueries.FollowPublication(r.Context(), database.FollowPublicationParams{
+			FollowerID:    userID,
+			PublicationID: publication.ID,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to follow publication")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Followed successfully"})
+	})))
+
+	// DELETE /api/publications/{slug}/follow - Unfollow a publication (auth required)
+	mux.Handle("DELETE /api/publications/{slug}/follow", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		publication, err := dbQueries.GetPublicationBySlug(r.Context(), r.PathValue("slug"))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Publication not found")
+			return
+		}
+
+		err = dbQueries.UnfollowPublication(r.Context(), database.UnfollowPublicationParams{
+			FollowerID:    userID,
+			PublicationID: publication.ID,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to unfollow publication")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Unfollowed successfully"})
+	})))
+
+	// POST /api/publications/{slug}/submissions - Submit a draft for review (members only)
+	mux.Handle("POST /api/publications/{slug}/submissions", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		publication, err := dbQueries.GetPublicationBySlug(r.Context(), r.PathValue("slug"))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Publication not found")
+			return
+		}
+
+		if publicationRole(r.Context(), dbQueries, publication.ID, userID) == "" {
+			respondError(w, http.StatusForbidden, "Only publication members can submit articles")
+			return
+		}
+
+		type request struct {
+			ArticleID uuid.UUID `json:"article_id"`
+			Note      string    `json:"note"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, req.ArticleID, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if !canPublishArticle(role) {
+			respondError(w, http.StatusForbidden, "Only the article's authors can submit it")
+			return
+		}
+
+		article, err := dbQueries.GetArticleByID(r.Context(), req.ArticleID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if article.Status != "draft" {
+			respondError(w, http.StatusBadRequest, "Only drafts can be submitted")
+			return
+		}
+
+		submission, err := dbQueries.UpsertSubmission(r.Context(), database.UpsertSubmissionParams{
+			PublicationID: publication.ID,
+			ArticleID:     article.ID,
+			SubmittedBy:   userID,
+			Note:          req.Note,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusConflict, "Article has already been accepted by this publication")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to submit article")
+			return
+		}
+
+		respondJSON(w, http.StatusCreated, submissionToResponse(submission))
+	})))
+
+	// GET /api/publications/{slug}/submissions - List submissions (editors see all, writers their own)
+	mux.Handle("GET /api/publications/{slug}/submissions", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		publication, err := dbQueries.GetPublicationBySlug(r.Context(), r.PathValue("slug"))
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Publication not found")
+			return
+		}
+
+		role := publicationRole(r.Context(), dbQueries, publication.ID, userID)
+		if role == "" {
+			respondError(w, http.StatusForbidden, "Only publication members can view submissions")
+			return
+		}
+
+		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+
+		params := database.ListPublicationSubmissionsParams{
+			PublicationID: publication.ID,
+			Status:        r.URL.Query().Get("status"),
+			Limit:         limit,
+			Offset:        offset,
+			CursorTime:    cursor.time(),
+			CursorID:      cursor.id(),
+		}
+		if !canReviewSubmissions(role) {
+			params.SubmittedBy = uuid.NullUUID{UUID: userID, Valid: true}
+		}
+
+		submissions, err := dbQueries.ListPublicationSubmissions(r.Context(), params)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch submissions")
+			return
+		}
+
+		result := make([]map[string]interface{}, 0, len(submissions))
+		for _, s := range submissions {
+			result = append(result, map[string]interface{}{
+				"id":            s.ID,
+				"article_id":    s.ArticleID,
+				"article_title": s.ArticleTitle,
+				"status":        s.Status,
+				"note":          s.Note,
+				"review_note":   s.ReviewNote,
+				"submitted_by": map[string]string{
+					"username": nullStringToStr(s.SubmitterUsername),
+					"name":     s.SubmitterName,
+				},
+				"created_at": s.CreatedAt,
+				"updated_at": s.UpdatedAt,
+			})
+		}
+
+		var nextCursor *string
+		if len(submissions) == int(limit) {
+			last := submissions[len(submissions)-1]
+			nextCursor = encodeCursor(pageCursor{Time: last.UpdatedAt, ID: last.ID})
+		}
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"submissions": result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
+		})
+	})))
+
+	// POST /api/submissions/{id}/request-changes - Send a submission back to its writer (editors only)
+	mux.Handle("POST /api/submissions/{id}/request-changes", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		submission, ok := getReviewableSubmission(w, r, dbQueries, userID)
+		if !ok {
+			return
+		}
+
+		type request struct {
+			Note string `json:"note"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if req.Note == "" {
+			respondError(w, http.StatusBadRequest, "A note describing the requested changes is required")
+			return
+		}
+		if submission.Status != "pending" {
+			respondError(w, http.StatusConflict, "Only pending submissions can be sent back")
+			return
+		}
+
+		submission, err := dbQueries.UpdateSubmissionStatus(r.Context(), database.UpdateSubmissionStatusParams{
+			ID:             submission.ID,
+			Status:         "changes_requested",
+			ReviewerID:     uuid.NullUUID{UUID: userID, Valid: true},
+			ReviewNote:     req.Note,
+			ExpectedStatus: "pending",
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusConflict, "Only pending submissions can be sent back")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to update submission")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, submissionToResponse(submission))
+	})))
+
+	// POST /api/submissions/{id}/accept - Accept a submission into the publication (editors only)
+	mux.Handle("POST /api/submissions/{id}/accept", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		submission, ok := getReviewableSubmission(w, r, dbQueries, userID)
+		if !ok {
+			return
+		}
+
+		if submission.Status != "pending" {
+			respondError(w, http.StatusConflict, "Only pending submissions can be accepted")
+			return
+		}
+
+		article, err := dbQueries.GetArticleByID(r.Context(), submission.ArticleID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if article.Status != "draft" {
+			respondError(w, http.StatusConflict, "Only drafts can be accepted")
+			return
+		}
+		if article.PublicationID.Valid {
+			respondError(w, http.StatusConflict, "Article already belongs to a publication")
+			return
+		}
+
+		// Rechecks both in the statement, so a concurrent review or an accept
+		// by another publication can't be overwritten
+		submission, err = dbQueries.AcceptSubmission(r.Context(), database.AcceptSubmissionParams{
+			ID:         submission.ID,
+			ReviewerID: uuid.NullUUID{UUID: userID, Valid: true},
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusConflict, "Submission or article changed during review; reload and try again")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to accept submission")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, submissionToResponse(submission))
+	})))
+
+	// POST /api/submissions/{id}/publish - Publish an accepted submission under the publication (editors only)
+	mux.Handle("POST /api/submissions/{id}/publish", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		submission, ok := getReviewableSubmission(w, r, dbQueries, userID)
+		if !ok {
+			return
+		}
+
+		if submission.Status != "accepted" {
+			respondError(w, http.StatusConflict, "Only accepted submissions can be published")
+			return
+		}
+
+		article, err := dbQueries.PublishArticle(r.Context(), database.PublishArticleParams{
+			ID:        submission.ArticleID,
+			DraftOnly: true,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusConflict, "Article has already been published")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to publish article")
+			return
+		}
+
+		_, err = dbQueries.UpdateSubmissionStatus(r.Context(), database.UpdateSubmissionStatusParams{
+			ID:             submission.ID,
+			Status:         "published",
+			ReviewerID:     uuid.NullUUID{UUID: userID, Valid: true},
+			ReviewNote:     submission.ReviewNote,
+			ExpectedStatus: "accepted",
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to update submission")
+			return
+		}
+
+		invalidateRelatedArticles(r.Context(), dbQueries, article.ID)
+
+		tags, _ := dbQueries.GetArticleTags(r.Context(), article.ID)
+		respondJSON(w, http.StatusOK, articleToResponse(article, tags, "", "", ""))
+	})))
+}
+
+// getReviewableSubmission loads the submission named in the path and checks that
+// the caller is an owner or editor of its publication, writing an error response
+// and returning false otherwise.
+func getReviewableSubmission(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, userID uuid.UUID) (database.PublicationSubmission, bool) {
+	id, err := getPathID(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid submission ID")
+		return database.PublicationSubmission{}, false
+	}
+
+	submission, err := dbQueries.GetSubmissionByID(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Submission not found")
+		return database.PublicationSubmission{}, false
+	}
+
+	if !canReviewSubmissions(publicationRole(r.Context(), dbQueries, submission.PublicationID, userID)) {
+		respondError(w, http.StatusForbidden, "Only publication editors can review submissions")
+		return database.PublicationSubmission{}, false
+	}
+
+	return submission, true
+}
+
+// publicationRole returns the user's role in a publication, or "" if they are not a member
+func publicationRole(ctx context.Context, dbQueries *database.Queries, publicationID, userID uuid.UUID) string {
+	role, err := dbQueries.GetPublicationMemberRole(ctx, database.GetPublicationMemberRoleParams{
+		PublicationID: publicationID,
+		UserID:        userID,
+	})
+	if err != nil {
+		return ""
+	}
+	return role
+}
+
+// canReviewSubmissions reports whether a publication role may review and publish submissions
+func canReviewSubmissions(role string) bool {
+	return role == "owner" || role == "editor"
+}
+
+// publicationRef builds the publication block of an article response, or nil if it has none
+func publicationRef(id uuid.NullUUID, name, slug sql.NullString) interface{} {
+	if !id.Valid {
+		return nil
+	}
+	return map[string]interface{}{
+		"id":   id.UUID,
+		"name": nullStringToStr(name),
+		"slug": nullStringToStr(slug),
+	}
+}
+
+// publicationToResponse converts a Publication model to a JSON-friendly response
+func publicationToResponse(p database.Publication) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          p.ID,
+		"slug":        p.Slug,
+		"name":        p.Name,
+		"description": p.Description,
+		"avatar_url":  p.AvatarUrl,
+		"created_at":  p.CreatedAt,
+		"updated_at":  p.UpdatedAt,
+	}
+}
+
+// submissionToResponse converts a PublicationSubmission model to a JSON-friendly response
+func submissionToResponse(s database.PublicationSubmission) map[string]interface{} {
+	return map[string]interface{}{
+		"id":             s.ID,
+		"publication_id": s.PublicationID,
+		"article_id":     s.ArticleID,
+		"submitted_by":   s.SubmittedBy,
+		"status":         s.Status,
+		"note":           s.Note,
+		"reviewer_id":    s.ReviewerID,
+		"review_note":    s.ReviewNote,
+		"created_at":     s.CreatedAt,
+		"updated_at":     s.UpdatedAt,
+	}
+}
@@ -0,0 +1,125 @@
+package routes
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/database"
+)
+
+// getRankingParams reads the sort and window query parameters. sort is
+// latest (the default), trending or top; window is day, week (the default)
+// or month and only applies to ranked sorts.
+func getRankingParams(r *http.Request) (sort, window string, ok bool) {
+	sort = r.URL.Query().Get("sort")
+	if sort == "" {
+		sort = "latest"
+	}
+	window = r.URL.Query().Get("window")
+	if window == "" {
+		window = "week"
+	}
+
+	validSort := sort == "latest" || sort == "trending" || sort == "top"
+	validWindow := window == "day" || window == "week" || window == "month"
+	return sort, window, validSort && validWindow
+}
+
+// respondRankedArticles lists published articles by their precomputed
+// trending or top score, optionally only those with a tag
+func respondRankedArticles(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, sort, window string, tagName sql.NullString) {
+	limit, offset := getPagination(r)
+	cursor, err := getCursor(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid cursor")
+		return
+	}
+	fields, includeBody := getFieldSelection(r)
+
+	articles, err := dbQueries.ListRankedArticles(r.Context(), database.ListRankedArticlesParams{
+		IncludeBody: includeBody,
+		ViewerID:    getViewerID(r),
+		Sort:        sort,
+		TimeWindow:  window,
+		TagName:     tagName,
+		Limit:       limit,
+		Offset:      offset,
+		CursorRank:  cursor.rank(),
+		CursorID:    cursor.id(),
+		// Later pages keep to the scores the first page was ranked by
+		CursorComputedAt: cursor.time(),
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(articles))
+	for _, a := range articles {
+		ids = append(ids, a.ID)
+	}
+	extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+	bookmarked := getBookmarkedArticles(r, dbQueries, ids)
+	access := getReaderAccess(r, dbQueries)
+
+	result := make([]map[string]interface{}, 0, len(articles))
+	for _, a := range articles {
+		item := articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
+			a.ThumbnailUrl, a.Status, a.PublishedAt, a.CreatedAt, a.UpdatedAt,
+			a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, a.ReadingTime,
+			a.PublicationID, a.PublicationName, a.PublicationSlug,
+			extras.tags[a.ID], extras.coAuthors[a.ID])
+		item["comment_count"] = a.CommentCount
+		item["canonical_url"] = a.CanonicalUrl
+		item["language"] = a.Language
+		item["original_published_at"] = nullTimeToPtr(a.OriginalPublishedAt)
+		setBookmarked(item, bookmarked, a.ID)
+		item["score"] = a.Score
+		applyVisibility(item, a.Visibility, a.Body, access.canRead(a.Visibility, a.UserID))
+		result = append(result, selectFields(item, fields, includeBody))
+	}
+	var nextCursor *string
+	if len(articles) == int(limit) {
+		last := articles[len(articles)-1]
+		nextCursor = encodeCursor(pageCursor{Time: last.ScoresComputedAt, Rank: last.Score, ID: last.ID})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"articles":    result,
+		"count":       len(result),
+		"next_cursor": nextCursor,
+	})
+}
+
+// refreshArticleScores computes a new generation of trending and top scores
+// and drops all but it and the one before
+func refreshArticleScores(ctx context.Context, dbQueries *database.Queries) error {
+	computedAt := time.Now().UTC()
+	if err := dbQueries.RefreshArticleScores(ctx, computedAt); err != nil {
+		return err
+	}
+	return dbQueries.DeleteStaleArticleScores(ctx, computedAt)
+}
+
+// RefreshArticleScoresEvery refreshes article scores now and then on every
+// interval until ctx is done
+func RefreshArticleScoresEvery(ctx context.Context, dbQueries *database.Queries, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := refreshArticleScores(ctx, dbQueries); err != nil {
+			log.Printf("Failed to refresh article scores: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
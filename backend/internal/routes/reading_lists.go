@@ -0,0 +1,555 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+// ReadingListRoutes sets up bookmark and reading list routes
+func ReadingListRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// GET /api/users/me/lists - List own reading lists, starting with the default one (auth required)
+	mux.Handle("GET /api/users/me/lists", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		// The default list is created on first use
+		if _, err := dbQueries.GetDefaultReadingList(r.Context(), userID); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch reading lists")
+			return
+		}
+
+		lists, err := dbQueries.ListReadingLists(r.Context(), userID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch reading lists")
+			return
+		}
+
+		result := make([]map[string]interface{}, 0, len(lists))
+		for _, l := range lists {
+			result = append(result, readingListToResponse(l.ReadingList, l.ItemCount))
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"lists": result,
+			"count": len(result),
+		})
+	})))
+
+	// POST /api/users/me/lists - Create a named reading list (auth required)
+	mux.Handle("POST /api/users/me/lists", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		type request struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			IsPublic    bool   `json:"is_public"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		req.Name = strings.TrimSpace(req.Name)
+		if msg := validateReadingListName(req.Name); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		list, err := dbQueries.CreateReadingList(r.Context(), database.CreateReadingListParams{
+			UserID:      userID,
+			Name:        req.Name,
+			Description: req.Description,
+			IsPublic:    req.IsPublic,
+		})
+		if isUniqueViolation(err) {
+			respondError(w, http.StatusConflict, "You already have a list with this name")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to create reading list")
+			return
+		}
+
+		respondJSON(w, http.StatusCreated, readingListToResponse(list, 0))
+	})))
+
+	// GET /api/users/me/lists/{id} - Get own reading list with its articles in order (auth required)
+	mux.Handle("GET /api/users/me/lists/{id}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		list, ok := getOwnReadingList(w, r, dbQueries, userID)
+		if !ok {
+			return
+		}
+
+		respondReadingList(w, r, dbQueries, list)
+	})))
+
+	// PUT /api/users/me/lists/{id} - Rename, describe or change the visibility of own reading list (auth required)
+	mux.Handle("PUT /api/users/me/lists/{id}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		type request struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			IsPublic    bool   `json:"is_public"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		req.Name = strings.TrimSpace(req.Name)
+		if msg := validateReadingListName(req.Name); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		existing, ok := getOwnReadingList(w, r, dbQueries, userID)
+		if !ok {
+			return
+		}
+
+		list, err := dbQueries.UpdateReadingList(r.Context(), database.UpdateReadingListParams{
+			ID:          existing.ReadingList.ID,
+			UserID:      userID,
+			Name:        req.Name,
+			Description: req.Description,
+			IsPublic:    req.IsPublic,
+		})
+		if isUniqueViolation(err) {
+			respondError(w, http.StatusConflict, "You already have a list with this name")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to update reading list")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, readingListToResponse(list, existing.ItemCount))
+	})))
+
+	// DELETE /api/users/me/lists/{id} - Delete own named reading list; the default list cannot be deleted (auth required)
+	mux.Handle("DELETE /api/users/me/lists/{id}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		list, ok := getOwnReadingList(w, r, dbQueries, userID)
+		if !ok {
+			return
+		}
+		if list.ReadingList.IsDefault {
+			respondError(w, http.StatusConflict, "The default reading list cannot be deleted")
+			return
+		}
+
+		_, err := dbQueries.DeleteReadingList(r.Context(), database.DeleteReadingListParams{
+			ID:     list.ReadingList.ID,
+			UserID: userID,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to delete reading list")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Reading list deleted successfully"})
+	})))
+
+	// POST /api/users/me/lists/{id}/articles - Add a published article to the end of own reading list (auth required)
+	mux.Handle("POST /api/users/me/lists/{id}/articles", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		type request struct {
+			ArticleID uuid.UUID `json:"article_id"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil || req.ArticleID == uuid.Nil {
+			respondError(w, http.StatusBadRequest, "An 'article_id' is required")
+			return
+		}
+
+		list, ok := getOwnReadingList(w, r, dbQueries, userID)
+		if !ok {
+			return
+		}
+
+		addToReadingList(w, r, dbQueries, list.ReadingList.ID, req.ArticleID)
+	})))
+
+	// DELETE /api/users/me/lists/{id}/articles/{articleId} - Remove an article from own reading list (auth required)
+	mux.Handle("DELETE /api/users/me/lists/{id}/articles/{articleId}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "articleId")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		list, ok := getOwnReadingList(w, r, dbQueries, userID)
+		if !ok {
+			return
+		}
+
+		removeFromReadingList(w, r, dbQueries, list.ReadingList.ID, articleID)
+	})))
+
+	// PUT /api/users/me/lists/{id}/order - Reorder own reading list, given all of its article IDs in order (auth required)
+	mux.Handle("PUT /api/users/me/lists/{id}/order", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		type request struct {
+			ArticleIDs []uuid.UUID `json:"article_ids"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		seen := make(map[uuid.UUID]bool, len(req.ArticleIDs))
+		for _, id := range req.ArticleIDs {
+			if seen[id] {
+				respondError(w, http.StatusBadRequest, "article_ids must not repeat an article")
+				return
+			}
+			seen[id] = true
+		}
+
+		list, ok := getOwnReadingList(w, r, dbQueries, userID)
+		if !ok {
+			return
+		}
+		if len(req.ArticleIDs) != int(list.ItemCount) {
+			respondError(w, http.StatusBadRequest, "article_ids must list every article in the reading list")
+			return
+		}
+
+		n, err := dbQueries.ReorderReadingListItems(r.Context(), database.ReorderReadingListItemsParams{
+			ListID:     list.ReadingList.ID,
+			ArticleIds: req.ArticleIDs,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to reorder reading list")
+			return
+		}
+		if n != int64(len(req.ArticleIDs)) {
+			respondError(w, http.StatusBadRequest, "article_ids must list every article in the reading list")
+			return
+		}
+
+		respondReadingList(w, r, dbQueries, list)
+	})))
+
+	// POST /api/articles/{id}/bookmark - Save an article to the default reading list (auth required)
+	mux.Handle("POST /api/articles/{id}/bookmark", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		list, err := dbQueries.GetDefaultReadingList(r.Context(), userID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to bookmark article")
+			return
+		}
+
+		addToReadingList(w, r, dbQueries, list.ID, articleID)
+	})))
+
+	// DELETE /api/articles/{id}/bookmark - Remove an article from the default reading list (auth required)
+	mux.Handle("DELETE /api/articles/{id}/bookmark", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		list, err := dbQueries.GetDefaultReadingList(r.Context(), userID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to remove bookmark")
+			return
+		}
+
+		removeFromReadingList(w, r, dbQueries, list.ID, articleID)
+	})))
+
+	// GET /api/users/{username}/lists - List a user's public reading lists
+	mux.HandleFunc("GET /api/users/{username}/lists", func(w http.ResponseWriter, r *http.Request) {
+		username := r.PathValue("username")
+
+		lists, err := dbQueries.ListPublicReadingLists(r.Context(), sqlNullString(username))
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch reading lists")
+			return
+		}
+
+		result := make([]map[string]interface{}, 0, len(lists))
+		for _, l := range lists {
+			result = append(result, readingListToResponse(l.ReadingList, l.ItemCount))
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"lists": result,
+			"count": len(result),
+		})
+	})
+
+	// GET /api/users/{username}/lists/{id} - Get a public reading list with its published articles (optional auth)
+	mux.Handle("GET /api/users/{username}/lists/{id}", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid list ID")
+			return
+		}
+
+		list, err := dbQueries.GetReadingList(r.Context(), id)
+		if err != nil || nullStringToStr(list.OwnerUsername) != r.PathValue("username") {
+			respondError(w, http.StatusNotFound, "Reading list not found")
+			return
+		}
+
+		// Private lists are only visible to their owner
+		viewerID, _ := middleware.GetUserID(r)
+		if !list.ReadingList.IsPublic && viewerID != list.ReadingList.UserID {
+			respondError(w, http.StatusNotFound, "Reading list not found")
+			return
+		}
+
+		respondReadingList(w, r, dbQueries, list)
+	})))
+}
+
+// getOwnReadingList loads the reading list named by the id path value,
+// writing an error response unless it belongs to userID
+func getOwnReadingList(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, userID uuid.UUID) (database.GetReadingListRow, bool) {
+	id, err := getPathID(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid list ID")
+		return database.GetReadingListRow{}, false
+	}
+
+	list, err := dbQueries.GetReadingList(r.Context(), id)
+	if err != nil || list.ReadingList.UserID != userID {
+		respondError(w, http.StatusNotFound, "Reading list not found")
+		return database.GetReadingListRow{}, false
+	}
+	return list, true
+}
+
+// addToReadingList adds a published article to a list
+func addToReadingList(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, listID, articleID uuid.UUID) {
+	article, err := dbQueries.GetArticleByID(r.Context(), articleID)
+	if err != nil || article.Status != "published" {
+		respondError(w, http.StatusNotFound, "Article not found")
+		return
+	}
+
+	n, err := dbQueries.AddReadingListItem(r.Context(), database.AddReadingListItemParams{
+		ListID:    listID,
+		ArticleID: articleID,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to add article to reading list")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"list_id":    listID,
+		"article_id": articleID,
+		"added":      n > 0,
+		"bookmarked": true,
+	})
+}
+
+// removeFromReadingList removes an article from a list
+func removeFromReadingList(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, listID, articleID uuid.UUID) {
+	n, err := dbQueries.RemoveReadingListItem(r.Context(), database.RemoveReadingListItemParams{
+		ListID:    listID,
+		ArticleID: articleID,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to remove article from reading list")
+		return
+	}
+	if n == 0 {
+		respondError(w, http.StatusNotFound, "Article is not in this reading list")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Article removed from reading list"})
+}
+
+// respondReadingList writes a reading list with a page of its articles
+func respondReadingList(w http.ResponseWriter, r *http.Request, dbQueries *database.Queries, list database.GetReadingListRow) {
+	limit, offset := getPagination(r)
+	cursor, err := getCursor(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid cursor")
+		return
+	}
+	fields, includeBody := getFieldSelection(r)
+
+	articles, err := dbQueries.ListReadingListArticles(r.Context(), database.ListReadingListArticlesParams{
+		IncludeBody:    includeBody,
+		ListID:         list.ReadingList.ID,
+		Limit:          limit,
+		Offset:         offset,
+		CursorPosition: cursor.position(),
+		CursorID:       cursor.id(),
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch reading list")
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(articles))
+	for _, a := range articles {
+		ids = append(ids, a.ID)
+	}
+	extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+	bookmarked := getBookmarkedArticles(r, dbQueries, ids)
+	access := getReaderAccess(r, dbQueries)
+
+	result := make([]map[string]interface{}, 0, len(articles))
+	for _, a := range articles {
+		item := articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
+			a.ThumbnailUrl, a.Status, a.PublishedAt, a.CreatedAt, a.UpdatedAt,
+			a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, a.ReadingTime,
+			a.PublicationID, a.PublicationName, a.PublicationSlug,
+			extras.tags[a.ID], extras.coAuthors[a.ID])
+		item["comment_count"] = a.CommentCount
+		item["canonical_url"] = a.CanonicalUrl
+		item["language"] = a.Language
+		item["original_published_at"] = nullTimeToPtr(a.OriginalPublishedAt)
+		setBookmarked(item, bookmarked, a.ID)
+		item["position"] = a.Position
+		item["added_at"] = a.AddedAt
+		applyVisibility(item, a.Visibility, a.Body, access.canRead(a.Visibility, a.UserID))
+		result = append(result, selectFields(item, fields, includeBody))
+	}
+
+	var nextCursor *string
+	if len(articles) == int(limit) {
+		last := articles[len(articles)-1]
+		nextCursor = encodeCursor(pageCursor{Position: last.Position, ID: last.ID})
+	}
+
+	resp := readingListToResponse(list.ReadingList, list.ItemCount)
+	resp["owner"] = nullStringToStr(list.OwnerUsername)
+	resp["articles"] = result
+	resp["count"] = len(result)
+	resp["next_cursor"] = nextCursor
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// getBookmarkedArticles returns which of the given articles the caller has
+// saved to any reading list, or nil for anonymous callers
+func getBookmarkedArticles(r *http.Request, dbQueries *database.Queries, ids []uuid.UUID) map[uuid.UUID]bool {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		return nil
+	}
+
+	bookmarked := make(map[uuid.UUID]bool, len(ids))
+	if len(ids) == 0 {
+		return bookmarked
+	}
+	saved, _ := dbQueries.ListBookmarkedArticleIDs(r.Context(), database.ListBookmarkedArticleIDsParams{
+		UserID:     userID,
+		ArticleIds: ids,
+	})
+	for _, id := range saved {
+		bookmarked[id] = true
+	}
+	return bookmarked
+}
+
+// setBookmarked adds the bookmarked flag to an article response for
+// signed-in callers
+func setBookmarked(item map[string]interface{}, bookmarked map[uuid.UUID]bool, id uuid.UUID) {
+	if bookmarked != nil {
+		item["bookmarked"] = bookmarked[id]
+	}
+}
+
+// validateReadingListName returns a message describing what is wrong with a
+// list name, or "" when it is valid
+func validateReadingListName(name string) string {
+	if name == "" {
+		return "List name is required"
+	}
+	if utf8.RuneCountInString(name) > 100 {
+		return "List name must be at most 100 characters"
+	}
+	return ""
+}
+
+// readingListToResponse converts a reading list to the JSON response format
+func readingListToResponse(l database.ReadingList, itemCount int32) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          l.ID,
+		"name":        l.Name,
+		"description": l.Description,
+		"is_public":   l.IsPublic,
+		"is_default":  l.IsDefault,
+		"item_count":  itemCount,
+		"created_at":  l.CreatedAt,
+		"updated_at":  l.UpdatedAt,
+	}
+}
@@ -0,0 +1,147 @@
+package routes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+const (
+	// relatedCacheSize is how many related articles are computed and cached
+	// per article; more than are shown so read ones can be left out
+	relatedCacheSize = 30
+	// defaultRelatedLimit and maxRelatedLimit bound the limit parameter
+	defaultRelatedLimit = 5
+	maxRelatedLimit     = 20
+)
+
+// RelatedRoutes sets up related article recommendation routes
+func RelatedRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// GET /api/articles/{id}/related?limit=5&finished=hide|last|show - Published articles related to an article, skipping ones the viewer has finished by default (optional auth)
+	mux.Handle("GET /api/articles/{id}/related", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		limit := defaultRelatedLimit
+		if l := r.URL.Query().Get("limit"); l != "" {
+			n, err := strconv.Atoi(l)
+			if err != nil || n < 1 || n > maxRelatedLimit {
+				respondError(w, http.StatusBadRequest, "limit must be between 1 and 20")
+				return
+			}
+			limit = n
+		}
+
+		finished, ok := getFinishedMode(r, "hide")
+		if !ok {
+			respondError(w, http.StatusBadRequest, "finished must be 'show', 'last' or 'hide'")
+			return
+		}
+
+		article, err := dbQueries.GetArticleByID(r.Context(), id)
+		if err != nil || article.Status != "published" {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+
+		relatedIDs, err := getRelatedArticleIDs(r.Context(), dbQueries, id)
+		if err != nil {
+			log.Printf("Failed to compute related articles for %s: %v", id, err)
+			respondError(w, http.StatusInternalServerError, "Failed to fetch related articles")
+			return
+		}
+
+		articles, err := dbQueries.ListRelatedArticles(r.Context(), database.ListRelatedArticlesParams{
+			Ids:      relatedIDs,
+			ViewerID: getViewerID(r),
+			Finished: finished,
+			Limit:    int32(limit),
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch related articles")
+			return
+		}
+
+		ids := make([]uuid.UUID, 0, len(articles))
+		for _, a := range articles {
+			ids = append(ids, a.ID)
+		}
+		extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+		bookmarked := getBookmarkedArticles(r, dbQueries, ids)
+		access := getReaderAccess(r, dbQueries)
+
+		result := make([]map[string]interface{}, 0, len(articles))
+		for _, a := range articles {
+			item := articleRowToResponse(a.ID, a.UserID, a.Title, "", a.Summary,
+				a.ThumbnailUrl, a.Status, a.PublishedAt, a.CreatedAt, a.UpdatedAt,
+				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, a.ReadingTime,
+				a.PublicationID, a.PublicationName, a.PublicationSlug,
+				extras.tags[a.ID], extras.coAuthors[a.ID])
+			item["comment_count"] = a.CommentCount
+			item["canonical_url"] = a.CanonicalUrl
+			item["language"] = a.Language
+			item["original_published_at"] = nullTimeToPtr(a.OriginalPublishedAt)
+			setBookmarked(item, bookmarked, a.ID)
+			applyVisibility(item, a.Visibility, "", access.canRead(a.Visibility, a.UserID))
+			delete(item, "body")
+			result = append(result, item)
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"articles": result,
+			"count":    len(result),
+		})
+	})))
+}
+
+// getRelatedArticleIDs returns the cached related article IDs for an
+// article, computing and caching them on a miss
+func getRelatedArticleIDs(ctx context.Context, dbQueries *database.Queries, articleID uuid.UUID) ([]uuid.UUID, error) {
+	ids, err := dbQueries.GetRelatedArticleCache(ctx, articleID)
+	if err == nil {
+		return ids, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	ids, err = dbQueries.ComputeRelatedArticles(ctx, database.ComputeRelatedArticlesParams{
+		ArticleID: articleID,
+		Limit:     relatedCacheSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		ids = []uuid.UUID{}
+	}
+
+	err = dbQueries.SetRelatedArticleCache(ctx, database.SetRelatedArticleCacheParams{
+		ArticleID:  articleID,
+		RelatedIds: ids,
+	})
+	if err != nil {
+		log.Printf("Failed to cache related articles for %s: %v", articleID, err)
+	}
+	return ids, nil
+}
+
+// invalidateRelatedArticles drops cached related articles that a newly
+// published article may now belong in. Failures are only logged; stale
+// entries expire on their own.
+func invalidateRelatedArticles(ctx context.Context, dbQueries *database.Queries, articleID uuid.UUID) {
+	if err := dbQueries.InvalidateRelatedArticleCache(ctx, articleID); err != nil {
+		log.Printf("Failed to invalidate related articles for %s: %v", articleID, err)
+	}
+}
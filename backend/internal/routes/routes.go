@@ -18,8 +18,35 @@ func SetupRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.Ap
 	// Article routes (CRUD, publish, drafts, feed, search)
 	ArticleRoutes(mux, dbQueries, cfg)
 
+	// Collaborator routes (invite, accept, remove)
+	CollaboratorRoutes(mux, dbQueries, cfg)
+
+	// Markdown import/export routes
+	ImportExportRoutes(mux, dbQueries, cfg)
+
+	// Import job routes (Medium and WordPress exports)
+	ImportRoutes(mux, dbQueries, cfg)
+
+	// Translation routes (link, unlink, list)
+	TranslationRoutes(mux, dbQueries, cfg)
+
+	// Share link routes (read-only draft access)
+	ShareLinkRoutes(mux, dbQueries, cfg)
+
+	// Reviewer note routes (private notes anchored to draft text)
+	NoteRoutes(mux, dbQueries, cfg)
+
+	// Reading list routes (bookmarks, named lists)
+	ReadingListRoutes(mux, dbQueries, cfg)
+
+	// Highlight routes (reader highlights with private notes)
+	HighlightRoutes(mux, dbQueries, cfg)
+
+	// Publication routes (members, follows, submissions)
+	PublicationRoutes(mux, dbQueries, cfg)
+
 	// Tag routes
-	TagRoutes(mux, dbQueries)
+	TagRoutes(mux, dbQueries, cfg)
 
 	// Comment routes
 	CommentRoutes(mux, dbQueries, cfg)
@@ -30,6 +57,18 @@ func SetupRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.Ap
 	// Follow routes
 	FollowRoutes(mux, dbQueries, cfg)
 
+	// Analytics routes (read beacon, author stats)
+	AnalyticsRoutes(mux, dbQueries, cfg)
+
+	// Reading history routes (progress beacon, history, pause)
+	HistoryRoutes(mux, dbQueries, cfg)
+
+	// Mute routes (authors, tags, keywords)
+	MuteRoutes(mux, dbQueries, cfg)
+
+	// Related article routes
+	RelatedRoutes(mux, dbQueries, cfg)
+
 	// Admin routes
 	AdminRoutes(mux, dbQueries, cfg)
 
@@ -0,0 +1,196 @@
+package routes
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/auth"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+// ShareLinkRoutes sets up draft share link routes (create, list, revoke)
+func ShareLinkRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// POST /api/articles/{id}/share-links - Create a read-only share link (owner, co-authors and editors)
+	mux.Handle("POST /api/articles/{id}/share-links", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		type request struct {
+			Label     string     `json:"label"`
+			ExpiresAt *time.Time `json:"expires_at"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		var expiresAt sql.NullTime
+		if req.ExpiresAt != nil {
+			if !req.ExpiresAt.After(time.Now()) {
+				respondError(w, http.StatusBadRequest, "expires_at must be in the future")
+				return
+			}
+			expiresAt = sql.NullTime{Time: req.ExpiresAt.UTC(), Valid: true}
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, articleID, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if !canEditArticle(role) {
+			respondError(w, http.StatusForbidden, "Not authorized to share this article")
+			return
+		}
+
+		link, err := dbQueries.CreateShareLink(r.Context(), database.CreateShareLinkParams{
+			ArticleID: articleID,
+			CreatedBy: userID,
+			Label:     req.Label,
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to create share link")
+			return
+		}
+
+		respondJSON(w, http.StatusCreated, map[string]interface{}{
+			"id":         link.ID,
+			"article_id": link.ArticleID,
+			"label":      link.Label,
+			"token":      auth.MakeShareToken(link.ID, cfg.JWTSecret),
+			"expires_at": nullTimeToPtr(link.ExpiresAt),
+			"created_at": link.CreatedAt,
+		})
+	})))
+
+	// GET /api/articles/{id}/share-links - List share links (owner, co-authors and editors)
+	mux.Handle("GET /api/articles/{id}/share-links", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, articleID, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if !canEditArticle(role) {
+			respondError(w, http.StatusForbidden, "Not authorized to view share links")
+			return
+		}
+
+		links, err := dbQueries.ListShareLinks(r.Context(), articleID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch share links")
+			return
+		}
+
+		result := make([]map[string]interface{}, 0, len(links))
+		for _, l := range links {
+			result = append(result, map[string]interface{}{
+				"id":         l.ID,
+				"label":      l.Label,
+				"token":      auth.MakeShareToken(l.ID, cfg.JWTSecret),
+				"created_by": nullStringToStr(l.CreatorUsername),
+				"expires_at": nullTimeToPtr(l.ExpiresAt),
+				"revoked_at": nullTimeToPtr(l.RevokedAt),
+				"created_at": l.CreatedAt,
+			})
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"share_links": result,
+			"count":       len(result),
+		})
+	})))
+
+	// DELETE /api/articles/{id}/share-links/{linkID} - Revoke a share link (owner, co-authors and editors)
+	mux.Handle("DELETE /api/articles/{id}/share-links/{linkID}", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		articleID, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		linkID, err := getPathID(r, "linkID")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid share link ID")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, articleID, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if !canEditArticle(role) {
+			respondError(w, http.StatusForbidden, "Not authorized to revoke share links")
+			return
+		}
+
+		revoked, err := dbQueries.RevokeShareLink(r.Context(), database.RevokeShareLinkParams{
+			ID:        linkID,
+			ArticleID: articleID,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to revoke share link")
+			return
+		}
+		if revoked == 0 {
+			respondError(w, http.StatusNotFound, "Share link not found")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Share link revoked successfully"})
+	})))
+}
+
+// getShareLink returns the active share link presented in the share query
+// parameter when it belongs to the given article
+func getShareLink(r *http.Request, dbQueries *database.Queries, cfg *config.ApiConfig, articleID uuid.UUID) (database.ArticleShareLink, bool) {
+	token := r.URL.Query().Get("share")
+	if token == "" {
+		return database.ArticleShareLink{}, false
+	}
+
+	linkID, err := auth.ValidateShareToken(token, cfg.JWTSecret)
+	if err != nil {
+		return database.ArticleShareLink{}, false
+	}
+
+	link, err := dbQueries.GetActiveShareLink(r.Context(), linkID)
+	if err != nil || link.ArticleID != articleID {
+		return database.ArticleShareLink{}, false
+	}
+	return link, true
+}
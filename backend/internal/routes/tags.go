@@ -1,13 +1,17 @@
 package routes
 
 import (
+	"database/sql"
 	"net/http"
 
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
 	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
 )
 
 // TagRoutes sets up tag-related routes
-func TagRoutes(mux *http.ServeMux, dbQueries *database.Queries) {
+func TagRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
 	// GET /api/tags - List all tags with article counts
 	mux.HandleFunc("GET /api/tags", func(w http.ResponseWriter, r *http.Request) {
 		tags, err := dbQueries.ListTags(r.Context())
@@ -30,36 +34,78 @@ func TagRoutes(mux *http.ServeMux, dbQueries *database.Queries) {
 		})
 	})
 
-	// GET /api/tags/{name}/articles - Get articles by tag
-	mux.HandleFunc("GET /api/tags/{name}/articles", func(w http.ResponseWriter, r *http.Request) {
+	// GET /api/tags/{name}/articles?sort=latest|trending|top&window=day|week|month - Get articles by tag
+	mux.Handle("GET /api/tags/{name}/articles", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		tagName := r.PathValue("name")
 		if tagName == "" {
 			respondError(w, http.StatusBadRequest, "Tag name is required")
 			return
 		}
 
+		sort, window, ok := getRankingParams(r)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "sort must be 'latest', 'trending' or 'top' and window 'day', 'week' or 'month'")
+			return
+		}
+		if sort != "latest" {
+			respondRankedArticles(w, r, dbQueries, sort, window, sql.NullString{String: tagName, Valid: true})
+			return
+		}
+
 		limit, offset := getPagination(r)
+		cursor, err := getCursor(r)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		fields, includeBody := getFieldSelection(r)
 
 		articles, err := dbQueries.ListArticlesByTag(r.Context(), database.ListArticlesByTagParams{
-			Lower:  tagName,
-			Limit:  limit,
-			Offset: offset,
+			IncludeBody: includeBody,
+			ViewerID:    getViewerID(r),
+			TagName:     tagName,
+			Limit:       limit,
+			Offset:      offset,
+			CursorTime:  cursor.time(),
+			CursorID:    cursor.id(),
 		})
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to fetch articles")
 			return
 		}
 
+		ids := make([]uuid.UUID, 0, len(articles))
+		for _, a := range articles {
+			ids = append(ids, a.ID)
+		}
+		extras := loadArticleListExtras(r.Context(), dbQueries, ids)
+		bookmarked := getBookmarkedArticles(r, dbQueries, ids)
+		access := getReaderAccess(r, dbQueries)
+
 		result := make([]map[string]interface{}, 0, len(articles))
 		for _, a := range articles {
-			tags, _ := dbQueries.GetArticleTags(r.Context(), a.ID)
-			result = append(result, articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
+			item := articleRowToResponse(a.ID, a.UserID, a.Title, a.Body, a.Summary,
 				a.ThumbnailUrl, a.Status, a.PublishedAt, a.CreatedAt, a.UpdatedAt,
-				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, tags))
+				a.AuthorUsername, a.AuthorName, a.AuthorAvatarUrl, a.TotalClaps, a.ReadingTime,
+				a.PublicationID, a.PublicationName, a.PublicationSlug,
+				extras.tags[a.ID], extras.coAuthors[a.ID])
+			item["comment_count"] = a.CommentCount
+			item["canonical_url"] = a.CanonicalUrl
+			item["language"] = a.Language
+			item["original_published_at"] = nullTimeToPtr(a.OriginalPublishedAt)
+			setBookmarked(item, bookmarked, a.ID)
+			applyVisibility(item, a.Visibility, a.Body, access.canRead(a.Visibility, a.UserID))
+			result = append(result, selectFields(item, fields, includeBody))
+		}
+		var nextCursor *string
+		if len(articles) == int(limit) {
+			last := articles[len(articles)-1]
+			nextCursor = encodeCursor(pageCursor{Time: last.PublishedAt.Time, ID: last.ID})
 		}
 		respondJSON(w, http.StatusOK, map[string]interface{}{
-			"articles": result,
-			"count":    len(result),
+			"articles":    result,
+			"count":       len(result),
+			"next_cursor": nextCursor,
 		})
-	})
+	})))
 }
@@ -0,0 +1,237 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jagjeevanak/golang-server/internal/config"
+	"github.com/jagjeevanak/golang-server/internal/database"
+	"github.com/jagjeevanak/golang-server/internal/language"
+	"github.com/jagjeevanak/golang-server/internal/middleware"
+)
+
+// TranslationRoutes sets up routes linking articles that are translations of each other
+func TranslationRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.ApiConfig) {
+	// GET /api/articles/{id}/translations - List an article's published translations, and drafts for its collaborators (optional auth)
+	mux.Handle("GET /api/articles/{id}/translations", middleware.OptionalAuth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		article, err := dbQueries.GetArticleByID(r.Context(), id)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+
+		var role string
+		if userID, ok := middleware.GetUserID(r); ok {
+			role, _ = articleRole(r.Context(), dbQueries, id, userID)
+		}
+		if article.Status == "draft" && role == "" {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+
+		translations, err := dbQueries.ListArticleTranslations(r.Context(), database.ListArticleTranslationsParams{
+			ID:            id,
+			IncludeDrafts: role != "",
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch translations")
+			return
+		}
+
+		result := translationsToResponse(translations)
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"translations": result,
+			"count":        len(result),
+		})
+	})))
+
+	// POST /api/articles/{id}/translations - Link another article as a translation (collaborators of both articles)
+	mux.Handle("POST /api/articles/{id}/translations", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		type request struct {
+			ArticleID uuid.UUID `json:"article_id"`
+		}
+
+		var req request
+		if err := decodeJSON(r, &req); err != nil || req.ArticleID == uuid.Nil {
+			respondError(w, http.StatusBadRequest, "A translation 'article_id' is required")
+			return
+		}
+		if req.ArticleID == id {
+			respondError(w, http.StatusBadRequest, "An article cannot be its own translation")
+			return
+		}
+
+		// Both articles must be editable by the caller
+		for _, articleID := range []uuid.UUID{id, req.ArticleID} {
+			role, err := articleRole(r.Context(), dbQueries, articleID, userID)
+			if err != nil {
+				respondError(w, http.StatusNotFound, "Article not found")
+				return
+			}
+			if !canEditArticle(role) {
+				respondError(w, http.StatusForbidden, "Not authorized to link translations of this article")
+				return
+			}
+		}
+
+		// The merged group may hold only one article per language
+		languages := make(map[string]uuid.UUID)
+		for _, articleID := range []uuid.UUID{id, req.ArticleID} {
+			group, err := translationGroupLanguages(r, dbQueries, articleID)
+			if err != nil {
+				respondError(w, http.StatusInternalServerError, "Failed to link translation")
+				return
+			}
+			for lang, memberID := range group {
+				if other, ok := languages[lang]; ok && other != memberID {
+					respondError(w, http.StatusConflict, "The translations already include an article in '"+lang+"'")
+					return
+				}
+				languages[lang] = memberID
+			}
+		}
+
+		err = dbQueries.LinkArticleTranslation(r.Context(), database.LinkArticleTranslationParams{
+			ArticleID:     id,
+			TranslationID: req.ArticleID,
+		})
+		if isUniqueViolation(err) {
+			respondError(w, http.StatusConflict, "The translations already include an article in this language")
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to link translation")
+			return
+		}
+
+		translations, err := dbQueries.ListArticleTranslations(r.Context(), database.ListArticleTranslationsParams{
+			ID:            id,
+			IncludeDrafts: true,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch translations")
+			return
+		}
+
+		result := translationsToResponse(translations)
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"translations": result,
+			"count":        len(result),
+		})
+	})))
+
+	// DELETE /api/articles/{id}/translations - Detach an article from its translations (owner, co-authors and editors)
+	mux.Handle("DELETE /api/articles/{id}/translations", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		id, err := getPathID(r, "id")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid article ID")
+			return
+		}
+
+		role, err := articleRole(r.Context(), dbQueries, id, userID)
+		if err != nil {
+			respondError(w, http.StatusNotFound, "Article not found")
+			return
+		}
+		if !canEditArticle(role) {
+			respondError(w, http.StatusForbidden, "Not authorized to unlink translations of this article")
+			return
+		}
+
+		n, err := dbQueries.UnlinkArticleTranslation(r.Context(), id)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to unlink translation")
+			return
+		}
+		if n == 0 {
+			respondError(w, http.StatusNotFound, "Article has no translations")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]string{"message": "Translation unlinked successfully"})
+	})))
+}
+
+// translationGroupLanguages maps the language of an article and of each of
+// its translations, drafts included, to the article in that language
+func translationGroupLanguages(r *http.Request, dbQueries *database.Queries, articleID uuid.UUID) (map[string]uuid.UUID, error) {
+	article, err := dbQueries.GetArticleByID(r.Context(), articleID)
+	if err != nil {
+		return nil, err
+	}
+	translations, err := dbQueries.ListArticleTranslations(r.Context(), database.ListArticleTranslationsParams{
+		ID:            articleID,
+		IncludeDrafts: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	languages := map[string]uuid.UUID{article.Language: article.ID}
+	for _, t := range translations {
+		languages[t.Language] = t.ID
+	}
+	return languages, nil
+}
+
+// negotiateTranslation returns the published translation whose language the
+// reader's Accept-Language header prefers over the article's own, if any
+func negotiateTranslation(r *http.Request, articleLanguage string, translations []database.ListArticleTranslationsRow) (uuid.UUID, bool) {
+	available := []string{articleLanguage}
+	for _, t := range translations {
+		if t.Status == "published" {
+			available = append(available, t.Language)
+		}
+	}
+
+	best := language.Negotiate(r.Header.Get("Accept-Language"), available)
+	if best == "" || best == articleLanguage {
+		return uuid.Nil, false
+	}
+	for _, t := range translations {
+		if t.Status == "published" && t.Language == best {
+			return t.ID, true
+		}
+	}
+	return uuid.Nil, false
+}
+
+// translationsToResponse converts an article's translations to the JSON response format
+func translationsToResponse(translations []database.ListArticleTranslationsRow) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(translations))
+	for _, t := range translations {
+		result = append(result, map[string]interface{}{
+			"id":           t.ID,
+			"title":        t.Title,
+			"language":     t.Language,
+			"status":       t.Status,
+			"slug":         nullStringToStr(t.Slug),
+			"published_at": nullTimeToPtr(t.PublishedAt),
+		})
+	}
+	return result
+}
@@ -1,7 +1,11 @@
 package routes
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/jagjeevanak/golang-server/internal/config"
 	"github.com/jagjeevanak/golang-server/internal/database"
@@ -24,17 +28,15 @@ func UserRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.Api
 			return
 		}
 
-		followerCount, _ := dbQueries.CountFollowers(r.Context(), user.ID)
-		followingCount, _ := dbQueries.CountFollowing(r.Context(), user.ID)
-
+		w.Header().Set("ETag", etagFromRevision(user.Revision))
 		respondJSON(w, http.StatusOK, map[string]interface{}{
 			"id":              user.ID,
 			"username":        nullStringToStr(user.Username),
 			"name":            user.Name,
 			"bio":             user.Bio,
 			"avatar_url":      user.AvatarUrl,
-			"follower_count":  followerCount,
-			"following_count": followingCount,
+			"follower_count":  user.FollowerCount,
+			"following_count": user.FollowingCount,
 			"created_at":      user.CreatedAt,
 		})
 	})
@@ -58,18 +60,110 @@ func UserRoutes(mux *http.ServeMux, dbQueries *database.Queries, cfg *config.Api
 			respondError(w, http.StatusBadRequest, "Invalid request body")
 			return
 		}
+		if msg := validateUserName(req.Name); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		expectedRevision, ok := getIfMatchRevision(r)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "Invalid If-Match header")
+			return
+		}
 
 		user, err := dbQueries.UpdateUserProfile(r.Context(), database.UpdateUserProfileParams{
-			ID:        userID,
-			Name:      req.Name,
-			Bio:       req.Bio,
-			AvatarUrl: req.AvatarUrl,
+			ID:               userID,
+			Name:             req.Name,
+			Bio:              req.Bio,
+			AvatarUrl:        req.AvatarUrl,
+			ExpectedRevision: expectedRevision,
 		})
+		if errors.Is(err, sql.ErrNoRows) && expectedRevision != 0 {
+			current, err := dbQueries.GetUserByID(r.Context(), userID)
+			if err != nil {
+				respondError(w, http.StatusNotFound, "User not found")
+				return
+			}
+			respondPreconditionFailed(w, current.Revision, userResponse(current))
+			return
+		}
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to update profile")
 			return
 		}
 
+		w.Header().Set("ETag", etagFromRevision(user.Revision))
 		respondJSON(w, http.StatusOK, userResponse(user))
 	})))
+
+	// PATCH /api/users - Partially update own profile with a JSON merge patch (auth required)
+	mux.Handle("PATCH /api/users", middleware.Auth(cfg.JWTSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := middleware.GetUserID(r)
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		patch, err := decodeMergePatch(r, "name", "bio", "avatar_url")
+		if err != nil {
+			respondPatchError(w, err)
+			return
+		}
+
+		params := database.PatchUserProfileParams{ID: userID}
+		for key, field := range map[string]*sql.NullString{
+			"name":       &params.Name,
+			"bio":        &params.Bio,
+			"avatar_url": &params.AvatarUrl,
+		} {
+			if *field, err = patchString(patch, key); err != nil {
+				respondPatchError(w, err)
+				return
+			}
+		}
+
+		// An absent name keeps the current one; null or "" would clear it
+		if params.Name.Valid {
+			if msg := validateUserName(params.Name.String); msg != "" {
+				respondError(w, http.StatusBadRequest, msg)
+				return
+			}
+		}
+
+		params.ExpectedRevision, ok = getIfMatchRevision(r)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "Invalid If-Match header")
+			return
+		}
+
+		user, err := dbQueries.PatchUserProfile(r.Context(), params)
+		if errors.Is(err, sql.ErrNoRows) && params.ExpectedRevision != 0 {
+			current, err := dbQueries.GetUserByID(r.Context(), userID)
+			if err != nil {
+				respondError(w, http.StatusNotFound, "User not found")
+				return
+			}
+			respondPreconditionFailed(w, current.Revision, userResponse(current))
+			return
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to update profile")
+			return
+		}
+
+		w.Header().Set("ETag", etagFromRevision(user.Revision))
+		respondJSON(w, http.StatusOK, userResponse(user))
+	})))
+}
+
+// validateUserName returns a message describing what is wrong with a display
+// name, or "" when it is valid
+func validateUserName(name string) string {
+	if strings.TrimSpace(name) == "" {
+		return "Name cannot be empty"
+	}
+	if utf8.RuneCountInString(name) > 100 {
+		return "Name must be at most 100 characters"
+	}
+	return ""
 }
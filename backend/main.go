@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/jagjeevanak/golang-server/internal/config"
 	"github.com/jagjeevanak/golang-server/internal/database"
@@ -39,13 +42,34 @@ func main() {
 	mux := http.NewServeMux()
 
 	apicfg := config.NewApiConfig(platform, jwtSecret)
+	apicfg.DB = db
+	if v := os.Getenv("COMMENT_MAX_DEPTH"); v != "" {
+		depth, err := strconv.Atoi(v)
+		if err != nil || depth < 0 {
+			log.Fatal("COMMENT_MAX_DEPTH must be a non-negative integer")
+		}
+		apicfg.CommentMaxDepth = depth
+	}
+	if v := os.Getenv("COMMENT_EDIT_WINDOW"); v != "" {
+		window, err := time.ParseDuration(v)
+		if err != nil || window < 0 {
+			log.Fatal("COMMENT_EDIT_WINDOW must be a non-negative duration such as 15m")
+		}
+		apicfg.CommentEditWindow = window
+	}
 
 	// Static file server with metrics
 	mux.Handle("/app/", middleware.Metrics(&apicfg.FileserverHits)(http.StripPrefix("/app", http.FileServer((http.Dir("."))))))
 
+	// Import jobs run in process, so any left over from a previous run were interrupted
+	routes.FailInterruptedImports(context.Background(), dbQueries)
+
 	// Setup all API routes
 	routes.SetupRoutes(mux, dbQueries, apicfg)
 
+	// Keep the trending and top article scores fresh
+	go routes.RefreshArticleScoresEvery(context.Background(), dbQueries, 10*time.Minute)
+
 	// Wrap with CORS and logging middleware
 	handler := middleware.CORS(middleware.Logger(mux))
 
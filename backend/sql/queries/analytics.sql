@@ -0,0 +1,83 @@
+-- name: RecordArticleView :execrows
+-- Records a viewer's first view of an article today and counts it in the
+-- daily rollup; repeat views the same day affect no rows.
+WITH inserted AS (
+    INSERT INTO article_views (article_id, day, viewer_hash)
+    VALUES (sqlc.arg(article_id), (NOW() AT TIME ZONE 'UTC')::date, sqlc.arg(viewer_hash))
+    ON CONFLICT DO NOTHING
+    RETURNING article_id, day
+)
+INSERT INTO article_daily_stats AS s (article_id, day, views)
+SELECT i.article_id, i.day, 1 FROM inserted i
+ON CONFLICT (article_id, day) DO UPDATE SET views = s.views + 1;
+
+-- name: RecordArticleRead :execrows
+-- Marks today's view by a viewer as read and counts it in the daily rollup.
+-- A read without a view today, or a repeated read, affects no rows.
+WITH marked AS (
+    UPDATE article_views v
+    SET read_at = NOW()
+    WHERE v.article_id = sqlc.arg(article_id)
+        AND v.day = (NOW() AT TIME ZONE 'UTC')::date
+        AND v.viewer_hash = sqlc.arg(viewer_hash)
+        AND v.read_at IS NULL
+    RETURNING v.article_id, v.day
+)
+INSERT INTO article_daily_stats AS s (article_id, day, reads)
+SELECT m.article_id, m.day, 1 FROM marked m
+ON CONFLICT (article_id, day) DO UPDATE SET reads = s.reads + 1;
+
+-- name: GetArticleDailyStats :many
+SELECT day, views, reads, claps
+FROM article_daily_stats
+WHERE article_id = sqlc.arg(article_id) AND day >= sqlc.arg(since)::date
+ORDER BY day;
+
+-- name: GetUserDailyStats :many
+-- Daily totals across the articles a user owns or co-authors, with followers
+-- gained and lost
+WITH articles_daily AS (
+    SELECT s.day,
+        SUM(s.views)::int AS views,
+        SUM(s.reads)::int AS reads,
+        SUM(s.claps)::int AS claps
+    FROM article_daily_stats s
+    JOIN articles a ON a.id = s.article_id
+    WHERE s.day >= sqlc.arg(since)::date AND (a.user_id = sqlc.arg(user_id) OR EXISTS (
+        SELECT 1 FROM article_collaborators ac
+        WHERE ac.article_id = a.id AND ac.user_id = sqlc.arg(user_id)
+            AND ac.role = 'co-author' AND ac.accepted_at IS NOT NULL
+    ))
+    GROUP BY s.day
+), followers_daily AS (
+    SELECT u.day, u.followers_gained, u.followers_lost
+    FROM user_daily_stats u
+    WHERE u.user_id = sqlc.arg(user_id) AND u.day >= sqlc.arg(since)::date
+)
+SELECT COALESCE(ad.day, fd.day)::date AS day,
+    COALESCE(ad.views, 0)::int AS views,
+    COALESCE(ad.reads, 0)::int AS reads,
+    COALESCE(ad.claps, 0)::int AS claps,
+    COALESCE(fd.followers_gained, 0)::int AS followers_gained,
+    COALESCE(fd.followers_lost, 0)::int AS followers_lost
+FROM articles_daily ad
+FULL OUTER JOIN followers_daily fd ON ad.day = fd.day
+ORDER BY 1;
+
+-- name: ListArticleStatsForUser :many
+-- Per-article totals over a period for the published articles a user owns or
+-- co-authors, most viewed first
+SELECT a.id, a.title, a.published_at,
+    COALESCE(SUM(s.views), 0)::int AS views,
+    COALESCE(SUM(s.reads), 0)::int AS reads,
+    COALESCE(SUM(s.claps), 0)::int AS claps
+FROM articles a
+LEFT JOIN article_daily_stats s ON s.article_id = a.id AND s.day >= sqlc.arg(since)::date
+WHERE a.status = 'published' AND (a.user_id = sqlc.arg(user_id) OR EXISTS (
+    SELECT 1 FROM article_collaborators ac
+    WHERE ac.article_id = a.id AND ac.user_id = sqlc.arg(user_id)
+        AND ac.role = 'co-author' AND ac.accepted_at IS NOT NULL
+))
+GROUP BY a.id
+ORDER BY 4 DESC, a.published_at DESC
+LIMIT 50;
@@ -1,6 +1,7 @@
 -- name: CreateArticle :one
-INSERT INTO articles (user_id, title, body, summary, thumbnail_url, status)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO articles (user_id, title, body, summary, thumbnail_url, status, visibility, slug,
+    canonical_url, original_published_at, language)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 RETURNING *;
 
 -- name: GetArticleByID :one
@@ -8,84 +9,203 @@ SELECT a.*,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    p.name AS publication_name,
+    p.slug AS publication_slug
 FROM articles a
 JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
 WHERE a.id = $1;
 
 -- name: ListPublishedArticles :many
-SELECT a.*,
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN sqlc.arg(include_body)::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
 FROM articles a
 JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
 WHERE a.status = 'published'
-ORDER BY a.published_at DESC
-LIMIT $1 OFFSET $2;
+    AND NOT article_muted(sqlc.narg(viewer_id)::uuid, a.id, a.user_id, a.title, a.summary)
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (a.published_at, a.id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid))
+ORDER BY a.published_at DESC, a.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: ListArticlesByAuthor :many
-SELECT a.*,
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN sqlc.arg(include_body)::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
 FROM articles a
 JOIN users u ON a.user_id = u.id
-WHERE u.username = $1 AND a.status = 'published'
-ORDER BY a.published_at DESC
-LIMIT $2 OFFSET $3;
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE a.status = 'published' AND (
+    u.username = sqlc.arg(username) OR EXISTS (
+        SELECT 1 FROM article_collaborators ac
+        JOIN users cu ON ac.user_id = cu.id
+        WHERE ac.article_id = a.id AND cu.username = sqlc.arg(username)
+            AND ac.role = 'co-author' AND ac.accepted_at IS NOT NULL
+    )
+)
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (a.published_at, a.id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid))
+ORDER BY a.published_at DESC, a.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: ListDraftsByUser :many
-SELECT a.*,
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN sqlc.arg(include_body)::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    0::int AS total_claps
+    0::int AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
 FROM articles a
 JOIN users u ON a.user_id = u.id
-WHERE a.user_id = $1 AND a.status = 'draft'
-ORDER BY a.updated_at DESC
-LIMIT $2 OFFSET $3;
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE a.status = 'draft' AND (
+    a.user_id = sqlc.arg(user_id) OR EXISTS (
+        SELECT 1 FROM article_collaborators ac
+        WHERE ac.article_id = a.id AND ac.user_id = sqlc.arg(user_id) AND ac.accepted_at IS NOT NULL
+    )
+)
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (a.updated_at, a.id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid))
+ORDER BY a.updated_at DESC, a.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: UpdateArticle :one
+-- A zero expected_revision skips the optimistic concurrency check.
+UPDATE articles
+SET title = sqlc.arg(title), body = sqlc.arg(body), summary = sqlc.arg(summary),
+    thumbnail_url = sqlc.arg(thumbnail_url),
+    visibility = COALESCE(sqlc.narg(visibility), visibility),
+    canonical_url = COALESCE(sqlc.narg(canonical_url), canonical_url),
+    original_published_at = COALESCE(sqlc.narg(original_published_at), original_published_at),
+    language = COALESCE(sqlc.narg(language), language),
+    revision = revision + 1, updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND (sqlc.arg(expected_revision)::int = 0 OR revision = sqlc.arg(expected_revision)::int)
+RETURNING *;
+
+-- name: PatchArticle :one
+-- Only non-null arguments are written; the rest keep their current values.
+-- original_published_at is written when set_original_published_at is true, so it can be cleared.
 UPDATE articles
-SET title = $2, body = $3, summary = $4, thumbnail_url = $5, updated_at = NOW()
-WHERE id = $1 AND user_id = $6
+SET title = COALESCE(sqlc.narg(title), title),
+    body = COALESCE(sqlc.narg(body), body),
+    summary = COALESCE(sqlc.narg(summary), summary),
+    thumbnail_url = COALESCE(sqlc.narg(thumbnail_url), thumbnail_url),
+    visibility = COALESCE(sqlc.narg(visibility), visibility),
+    canonical_url = COALESCE(sqlc.narg(canonical_url), canonical_url),
+    original_published_at = CASE WHEN sqlc.arg(set_original_published_at)::bool
+        THEN sqlc.narg(original_published_at)::timestamp ELSE original_published_at END,
+    language = COALESCE(sqlc.narg(language), language),
+    revision = revision + 1,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND (sqlc.arg(expected_revision)::int = 0 OR revision = sqlc.arg(expected_revision)::int)
 RETURNING *;
 
 -- name: PublishArticle :one
+-- published_at defaults to now; imports pass the document's own date.
+-- draft_only leaves an already published article alone.
 UPDATE articles
-SET status = 'published', published_at = NOW(), updated_at = NOW()
-WHERE id = $1 AND user_id = $2
+SET status = 'published', published_at = COALESCE(sqlc.narg(published_at)::timestamp, NOW()),
+    revision = revision + 1, updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND (sqlc.arg(expected_revision)::int = 0 OR revision = sqlc.arg(expected_revision)::int)
+    AND (NOT sqlc.arg(draft_only)::bool OR status = 'draft')
 RETURNING *;
 
--- name: DeleteArticle :exec
+-- name: DeleteArticle :execrows
 DELETE FROM articles
-WHERE id = $1 AND user_id = $2;
+WHERE id = sqlc.arg(id)
+    AND (sqlc.arg(expected_revision)::int = 0 OR revision = sqlc.arg(expected_revision)::int);
 
 -- name: SearchArticles :many
-SELECT a.*,
+-- With a language, only articles in it match, stemmed for it; without one the
+-- query is stemmed for every supported language.
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN sqlc.arg(include_body)::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug,
+    ts_rank(a.search_vector, article_tsquery(sqlc.narg(lang), sqlc.arg(query)))::real AS rank
 FROM articles a
 JOIN users u ON a.user_id = u.id
-WHERE a.status = 'published' AND a.search_vector @@ plainto_tsquery('english', $1)
-ORDER BY ts_rank(a.search_vector, plainto_tsquery('english', $1)) DESC
-LIMIT $2 OFFSET $3;
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE a.status = 'published' AND a.search_vector @@ article_tsquery(sqlc.narg(lang), sqlc.arg(query))
+    AND (sqlc.narg(lang)::text IS NULL OR a.language = sqlc.narg(lang))
+    AND NOT article_muted(sqlc.narg(viewer_id)::uuid, a.id, a.user_id, a.title, a.summary)
+    AND (sqlc.narg(cursor_rank)::real IS NULL
+        OR (ts_rank(a.search_vector, article_tsquery(sqlc.narg(lang), sqlc.arg(query))), a.id)
+            < (sqlc.narg(cursor_rank)::real, sqlc.narg(cursor_id)::uuid))
+ORDER BY rank DESC, a.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: GetFeedArticles :many
-SELECT a.*,
+-- Articles the follower has finished are listed as usual, last or not at all
+-- when finished is show, last or hide.
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN sqlc.arg(include_body)::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug,
+    (r.finished_at IS NOT NULL)::bool AS finished
 FROM articles a
 JOIN users u ON a.user_id = u.id
-JOIN follows f ON f.following_id = a.user_id
-WHERE f.follower_id = $1 AND a.status = 'published'
-ORDER BY a.published_at DESC
-LIMIT $2 OFFSET $3;
+LEFT JOIN publications p ON a.publication_id = p.id
+LEFT JOIN article_reads r ON r.article_id = a.id AND r.user_id = sqlc.arg(follower_id)
+WHERE a.status = 'published' AND (
+    a.user_id IN (SELECT f.following_id FROM follows f WHERE f.follower_id = sqlc.arg(follower_id))
+    OR a.publication_id IN (SELECT pf.publication_id FROM publication_follows pf WHERE pf.follower_id = sqlc.arg(follower_id))
+)
+    AND NOT article_muted(sqlc.arg(follower_id)::uuid, a.id, a.user_id, a.title, a.summary)
+    AND (sqlc.arg(finished)::text <> 'hide' OR r.finished_at IS NULL)
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (sqlc.arg(finished)::text = 'last' AND (r.finished_at IS NOT NULL) AND NOT sqlc.arg(cursor_finished)::bool)
+        OR ((sqlc.arg(finished)::text <> 'last' OR (r.finished_at IS NOT NULL) = sqlc.arg(cursor_finished)::bool)
+            AND (a.published_at, a.id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid)))
+ORDER BY (CASE WHEN sqlc.arg(finished)::text = 'last' AND r.finished_at IS NOT NULL THEN 1 ELSE 0 END),
+    a.published_at DESC, a.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: GetArticleBySlug :one
+SELECT * FROM articles
+WHERE user_id = $1 AND slug = $2;
+
+-- name: SetArticleSlug :exec
+UPDATE articles
+SET slug = $2
+WHERE id = $1;
+
+-- name: ListArticlesForExport :many
+SELECT * FROM articles
+WHERE user_id = $1
+ORDER BY created_at ASC;
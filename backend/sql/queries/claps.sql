@@ -1,15 +1,42 @@
 -- name: UpsertClap :one
-INSERT INTO claps (article_id, user_id, count)
-VALUES ($1, $2, $3)
-ON CONFLICT (article_id, user_id) DO UPDATE
-SET count = LEAST(claps.count + EXCLUDED.count, 50),
-    updated_at = NOW()
-RETURNING *;
+-- Adds claps and applies the change to the article's clap and clapper
+-- counters and today's stats rollup in the same statement. A reader's first
+-- clap inserts their row (xmax = 0) and counts them as a clapper. Later claps
+-- lock the previous row so concurrent claps see each other's totals; a row
+-- that another request inserted after this statement's snapshot is left
+-- alone and nothing is returned, so the caller can retry.
+WITH previous AS (
+    SELECT c.count FROM claps c
+    WHERE c.article_id = sqlc.arg(article_id) AND c.user_id = sqlc.arg(user_id)
+    FOR UPDATE
+), upserted AS (
+    INSERT INTO claps (article_id, user_id, count)
+    VALUES (sqlc.arg(article_id), sqlc.arg(user_id), sqlc.arg(count))
+    ON CONFLICT (article_id, user_id) DO UPDATE
+    SET count = LEAST(claps.count + EXCLUDED.count, 50),
+        updated_at = NOW()
+    WHERE EXISTS (SELECT 1 FROM previous)
+    RETURNING claps.*, (claps.xmax = 0) AS inserted
+), counters AS (
+    UPDATE articles a
+    SET clap_count = a.clap_count + u.count - COALESCE((SELECT p.count FROM previous p), 0),
+        clapper_count = a.clapper_count + (CASE WHEN u.inserted THEN 1 ELSE 0 END)
+    FROM upserted u
+    WHERE a.id = u.article_id
+), daily AS (
+    INSERT INTO article_daily_stats AS s (article_id, day, claps, clappers)
+    SELECT u.article_id, (NOW() AT TIME ZONE 'UTC')::date, u.count - COALESCE((SELECT p.count FROM previous p), 0),
+        (CASE WHEN u.inserted THEN 1 ELSE 0 END)
+    FROM upserted u
+    ON CONFLICT (article_id, day) DO UPDATE
+    SET claps = s.claps + EXCLUDED.claps, clappers = s.clappers + EXCLUDED.clappers
+)
+SELECT u.id, u.article_id, u.user_id, u.count, u.created_at, u.updated_at FROM upserted u;
 
 -- name: GetArticleClapCount :one
-SELECT COALESCE(SUM(count), 0)::int AS total_claps
-FROM claps
-WHERE article_id = $1;
+SELECT clap_count AS total_claps
+FROM articles
+WHERE id = $1;
 
 -- name: GetUserClapForArticle :one
 SELECT COALESCE(count, 0)::int AS user_claps
@@ -0,0 +1,82 @@
+-- name: InviteCollaborator :one
+-- Re-inviting a user whose invitation is still pending replaces it; an
+-- accepted collaborator is left unchanged and no row is returned.
+INSERT INTO article_collaborators (article_id, user_id, role, invited_by)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (article_id, user_id) DO UPDATE
+SET role = EXCLUDED.role
+WHERE article_collaborators.accepted_at IS NULL
+RETURNING *;
+
+-- name: AcceptCollaboration :one
+UPDATE article_collaborators
+SET accepted_at = NOW()
+WHERE article_id = $1 AND user_id = $2 AND accepted_at IS NULL
+RETURNING *;
+
+-- name: RemoveCollaborator :exec
+DELETE FROM article_collaborators
+WHERE article_id = $1 AND user_id = $2;
+
+-- name: GetArticleRole :one
+-- Editors and owners of the article's publication, or of one still reviewing
+-- it, act as its editors.
+SELECT (CASE
+    WHEN a.user_id = $2 THEN 'owner'
+    ELSE COALESCE(
+        (
+            SELECT ac.role FROM article_collaborators ac
+            WHERE ac.article_id = a.id AND ac.user_id = $2 AND ac.accepted_at IS NOT NULL
+        ),
+        (
+            SELECT 'editor' FROM publication_members pm
+            WHERE pm.user_id = $2 AND pm.role IN ('owner', 'editor') AND (
+                pm.publication_id = a.publication_id OR EXISTS (
+                    SELECT 1 FROM publication_submissions ps
+                    WHERE ps.article_id = a.id AND ps.publication_id = pm.publication_id
+                        AND ps.status IN ('pending', 'changes_requested', 'accepted')
+                )
+            )
+            LIMIT 1
+        ),
+        ''
+    )
+END)::text AS role
+FROM articles a
+WHERE a.id = $1;
+
+-- name: ListArticleCollaborators :many
+SELECT ac.*,
+    u.username,
+    u.name,
+    u.avatar_url
+FROM article_collaborators ac
+JOIN users u ON ac.user_id = u.id
+WHERE ac.article_id = $1
+ORDER BY ac.created_at ASC;
+
+-- name: ListArticleCoAuthors :many
+SELECT u.id, u.username, u.name, u.avatar_url
+FROM users u
+JOIN article_collaborators ac ON ac.user_id = u.id
+WHERE ac.article_id = $1 AND ac.role = 'co-author' AND ac.accepted_at IS NOT NULL
+ORDER BY ac.accepted_at ASC;
+
+-- name: ListCoAuthorsForArticles :many
+SELECT ac.article_id, u.id, u.username, u.name, u.avatar_url
+FROM article_collaborators ac
+JOIN users u ON ac.user_id = u.id
+WHERE ac.article_id = ANY(sqlc.arg(article_ids)::uuid[])
+    AND ac.role = 'co-author' AND ac.accepted_at IS NOT NULL
+ORDER BY ac.article_id, ac.accepted_at ASC;
+
+-- name: ListPendingInvitations :many
+SELECT ac.*,
+    a.title AS article_title,
+    u.username AS inviter_username,
+    u.name AS inviter_name
+FROM article_collaborators ac
+JOIN articles a ON ac.article_id = a.id
+JOIN users u ON ac.invited_by = u.id
+WHERE ac.user_id = $1 AND ac.accepted_at IS NULL
+ORDER BY ac.created_at DESC;
@@ -1,32 +1,116 @@
 -- name: CreateComment :one
-INSERT INTO comments (article_id, user_id, body)
-VALUES ($1, $2, $3)
-RETURNING *;
+-- Inserts a comment and bumps the article's comment counter and today's stats
+-- rollup in one statement
+WITH inserted AS (
+    INSERT INTO comments (article_id, user_id, body, parent_id, depth)
+    VALUES (sqlc.arg(article_id), sqlc.arg(user_id), sqlc.arg(body), sqlc.narg(parent_id), sqlc.arg(depth))
+    RETURNING *
+), counters AS (
+    UPDATE articles SET comment_count = comment_count + 1
+    WHERE id = sqlc.arg(article_id)
+), daily AS (
+    INSERT INTO article_daily_stats AS s (article_id, day, comments)
+    VALUES (sqlc.arg(article_id), (NOW() AT TIME ZONE 'UTC')::date, 1)
+    ON CONFLICT (article_id, day) DO UPDATE SET comments = s.comments + 1
+)
+SELECT * FROM inserted;
 
 -- name: GetCommentByID :one
 SELECT c.*,
     u.username AS author_username,
     u.name AS author_name,
-    u.avatar_url AS author_avatar_url
+    u.avatar_url AS author_avatar_url,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id
+        AND (r.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments rr WHERE rr.parent_id = r.id)))::int AS reply_count
 FROM comments c
 JOIN users u ON c.user_id = u.id
 WHERE c.id = $1;
 
 -- name: ListCommentsByArticle :many
+-- Top-level comments with their reply counts. Comments by authors the viewer
+-- has muted are flagged so clients collapse them, and deleted comments are
+-- kept as placeholders only while they have replies.
 SELECT c.*,
     u.username AS author_username,
     u.name AS author_name,
-    u.avatar_url AS author_avatar_url
+    u.avatar_url AS author_avatar_url,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id
+        AND (r.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments rr WHERE rr.parent_id = r.id)))::int AS reply_count,
+    EXISTS (
+        SELECT 1 FROM muted_authors ma
+        WHERE ma.user_id = sqlc.narg(viewer_id)::uuid AND ma.muted_user_id = c.user_id
+    )::bool AS collapsed
 FROM comments c
 JOIN users u ON c.user_id = u.id
-WHERE c.article_id = $1
-ORDER BY c.created_at ASC
-LIMIT $2 OFFSET $3;
+WHERE c.article_id = sqlc.arg(article_id) AND c.parent_id IS NULL
+    AND (c.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = c.id))
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (c.created_at, c.id) > (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid))
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
--- name: DeleteComment :exec
-DELETE FROM comments
-WHERE id = $1 AND user_id = $2;
+-- name: ListCommentReplies :many
+-- Direct replies to a comment, oldest first, flagged and pruned as in ListCommentsByArticle
+SELECT c.*,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id
+        AND (r.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments rr WHERE rr.parent_id = r.id)))::int AS reply_count,
+    EXISTS (
+        SELECT 1 FROM muted_authors ma
+        WHERE ma.user_id = sqlc.narg(viewer_id)::uuid AND ma.muted_user_id = c.user_id
+    )::bool AS collapsed
+FROM comments c
+JOIN users u ON c.user_id = u.id
+WHERE c.parent_id = sqlc.arg(parent_id)
+    AND (c.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = c.id))
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (c.created_at, c.id) > (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid))
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: DeleteComment :execrows
+-- Deletes a comment and decrements the article's comment counter in one
+-- statement. A comment with replies is blanked into a placeholder instead.
+WITH target AS (
+    SELECT c.id, c.article_id,
+        EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = c.id) AS has_replies
+    FROM comments c
+    WHERE c.id = $1 AND c.user_id = $2 AND c.deleted_at IS NULL
+), removed AS (
+    DELETE FROM comments c
+    USING target t
+    WHERE c.id = t.id AND NOT t.has_replies
+), blanked AS (
+    UPDATE comments c SET body = '', deleted_at = NOW(), updated_at = NOW()
+    FROM target t
+    WHERE c.id = t.id AND t.has_replies
+)
+UPDATE articles a SET comment_count = a.comment_count - 1
+WHERE a.id IN (SELECT t.article_id FROM target t);
+
+-- name: UpdateComment :one
+-- Replaces the body of an author's comment, keeping the previous version.
+-- A NULL edit window means comments can be edited at any time.
+WITH previous AS (
+    SELECT c.id, c.body FROM comments c
+    WHERE c.id = sqlc.arg(id) AND c.user_id = sqlc.arg(user_id) AND c.deleted_at IS NULL
+      AND (sqlc.narg(edit_window_seconds)::float8 IS NULL
+           OR c.created_at > NOW() - make_interval(secs => sqlc.narg(edit_window_seconds)::float8))
+    FOR UPDATE
+), archived AS (
+    INSERT INTO comment_edits (comment_id, body)
+    SELECT p.id, p.body FROM previous p
+)
+UPDATE comments c
+SET body = sqlc.arg(body), edited_at = NOW(), updated_at = NOW()
+FROM previous p
+WHERE c.id = p.id
+RETURNING c.*;
 
--- name: CountCommentsByArticle :one
-SELECT COUNT(*)::int FROM comments
-WHERE article_id = $1;
+-- name: ListCommentEdits :many
+-- Earlier versions of a comment, oldest first
+SELECT * FROM comment_edits
+WHERE comment_id = $1
+ORDER BY replaced_at ASC, id ASC;
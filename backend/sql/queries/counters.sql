@@ -0,0 +1,57 @@
+-- name: ReconcileArticleCounters :many
+-- Recomputes every article's engagement counters from the source tables and
+-- returns the stored and actual values for the rows that had drifted
+WITH actual AS (
+    SELECT a.id,
+        COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS clap_count,
+        (SELECT COUNT(*) FROM claps c WHERE c.article_id = a.id)::int AS clapper_count,
+        (SELECT COUNT(*) FROM comments c WHERE c.article_id = a.id AND c.deleted_at IS NULL)::int AS comment_count
+    FROM articles a
+), drifted AS (
+    SELECT a.id,
+        a.clap_count AS stored_clap_count,
+        a.clapper_count AS stored_clapper_count,
+        a.comment_count AS stored_comment_count,
+        t.clap_count, t.clapper_count, t.comment_count
+    FROM articles a
+    JOIN actual t ON t.id = a.id
+    WHERE a.clap_count <> t.clap_count
+        OR a.clapper_count <> t.clapper_count
+        OR a.comment_count <> t.comment_count
+)
+UPDATE articles a
+SET clap_count = d.clap_count,
+    clapper_count = d.clapper_count,
+    comment_count = d.comment_count
+FROM drifted d
+WHERE a.id = d.id
+RETURNING a.id, a.title,
+    d.stored_clap_count, a.clap_count,
+    d.stored_clapper_count, a.clapper_count,
+    d.stored_comment_count, a.comment_count;
+
+-- name: ReconcileUserCounters :many
+-- Recomputes every user's follow counters and returns the rows that had drifted
+WITH actual AS (
+    SELECT u.id,
+        (SELECT COUNT(*) FROM follows f WHERE f.following_id = u.id)::int AS follower_count,
+        (SELECT COUNT(*) FROM follows f WHERE f.follower_id = u.id)::int AS following_count
+    FROM users u
+), drifted AS (
+    SELECT u.id,
+        u.follower_count AS stored_follower_count,
+        u.following_count AS stored_following_count,
+        t.follower_count, t.following_count
+    FROM users u
+    JOIN actual t ON t.id = u.id
+    WHERE u.follower_count <> t.follower_count
+        OR u.following_count <> t.following_count
+)
+UPDATE users u
+SET follower_count = d.follower_count,
+    following_count = d.following_count
+FROM drifted d
+WHERE u.id = d.id
+RETURNING u.id, u.username,
+    d.stored_follower_count, u.follower_count,
+    d.stored_following_count, u.following_count;
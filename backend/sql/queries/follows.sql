@@ -1,11 +1,40 @@
 -- name: FollowUser :exec
-INSERT INTO follows (follower_id, following_id)
-VALUES ($1, $2)
-ON CONFLICT DO NOTHING;
+-- Creates the follow and updates both users' counters and the followed
+-- user's stats rollup in one statement; a repeated follow inserts nothing and
+-- leaves the counters alone
+WITH inserted AS (
+    INSERT INTO follows (follower_id, following_id)
+    VALUES ($1, $2)
+    ON CONFLICT DO NOTHING
+    RETURNING follower_id, following_id
+), followers AS (
+    UPDATE users SET follower_count = follower_count + 1
+    WHERE id IN (SELECT following_id FROM inserted)
+), daily AS (
+    INSERT INTO user_daily_stats AS s (user_id, day, followers_gained)
+    SELECT i.following_id, (NOW() AT TIME ZONE 'UTC')::date, 1 FROM inserted i
+    ON CONFLICT (user_id, day) DO UPDATE SET followers_gained = s.followers_gained + 1
+)
+UPDATE users SET following_count = following_count + 1
+WHERE id IN (SELECT follower_id FROM inserted);
 
 -- name: UnfollowUser :exec
-DELETE FROM follows
-WHERE follower_id = $1 AND following_id = $2;
+-- Removes the follow and updates both users' counters and the followed
+-- user's stats rollup in one statement
+WITH deleted AS (
+    DELETE FROM follows
+    WHERE follower_id = $1 AND following_id = $2
+    RETURNING follower_id, following_id
+), followers AS (
+    UPDATE users SET follower_count = follower_count - 1
+    WHERE id IN (SELECT following_id FROM deleted)
+), daily AS (
+    INSERT INTO user_daily_stats AS s (user_id, day, followers_lost)
+    SELECT d.following_id, (NOW() AT TIME ZONE 'UTC')::date, 1 FROM deleted d
+    ON CONFLICT (user_id, day) DO UPDATE SET followers_lost = s.followers_lost + 1
+)
+UPDATE users SET following_count = following_count - 1
+WHERE id IN (SELECT follower_id FROM deleted);
 
 -- name: IsFollowing :one
 SELECT EXISTS(
@@ -14,25 +43,21 @@ SELECT EXISTS(
 )::bool AS is_following;
 
 -- name: ListFollowers :many
-SELECT u.id, u.username, u.name, u.avatar_url, u.bio
+SELECT u.id, u.username, u.name, u.avatar_url, u.bio, f.created_at AS followed_at
 FROM users u
 JOIN follows f ON f.follower_id = u.id
-WHERE f.following_id = $1
-ORDER BY f.created_at DESC
-LIMIT $2 OFFSET $3;
+WHERE f.following_id = sqlc.arg(following_id)
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (f.created_at, u.id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid))
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: ListFollowing :many
-SELECT u.id, u.username, u.name, u.avatar_url, u.bio
+SELECT u.id, u.username, u.name, u.avatar_url, u.bio, f.created_at AS followed_at
 FROM users u
 JOIN follows f ON f.following_id = u.id
-WHERE f.follower_id = $1
-ORDER BY f.created_at DESC
-LIMIT $2 OFFSET $3;
-
--- name: CountFollowers :one
-SELECT COUNT(*)::int FROM follows
-WHERE following_id = $1;
-
--- name: CountFollowing :one
-SELECT COUNT(*)::int FROM follows
-WHERE follower_id = $1;
+WHERE f.follower_id = sqlc.arg(follower_id)
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (f.created_at, u.id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid))
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,39 @@
+-- name: CreateHighlight :one
+INSERT INTO article_highlights (article_id, user_id, anchor_start, anchor_end, quote, note)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListTopHighlights :many
+-- The most highlighted ranges of an article, counting each reader once
+SELECT h.anchor_start, h.anchor_end, h.quote,
+    COUNT(DISTINCT h.user_id)::int AS highlight_count
+FROM article_highlights h
+WHERE h.article_id = sqlc.arg(article_id) AND NOT h.detached
+GROUP BY h.anchor_start, h.anchor_end, h.quote
+ORDER BY highlight_count DESC, h.anchor_start ASC
+LIMIT sqlc.arg('limit');
+
+-- name: ListUserHighlights :many
+SELECT * FROM article_highlights
+WHERE article_id = $1 AND user_id = $2
+ORDER BY detached ASC, anchor_start ASC, created_at ASC;
+
+-- name: UpdateHighlightNote :one
+UPDATE article_highlights
+SET note = $3, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteHighlight :execrows
+DELETE FROM article_highlights
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListHighlightAnchors :many
+SELECT id, anchor_start, anchor_end, quote
+FROM article_highlights
+WHERE article_id = $1 AND NOT detached;
+
+-- name: UpdateHighlightAnchor :exec
+UPDATE article_highlights
+SET anchor_start = $2, anchor_end = $3, detached = $4, updated_at = NOW()
+WHERE id = $1;
@@ -0,0 +1,75 @@
+-- name: RecordArticleOpen :execrows
+-- Adds an article a reader opened to their history, unless it is paused
+INSERT INTO article_reads AS ar (user_id, article_id)
+SELECT u.id, sqlc.arg(article_id) FROM users u
+WHERE u.id = sqlc.arg(user_id) AND NOT u.history_paused
+ON CONFLICT (user_id, article_id) DO UPDATE SET read_at = NOW();
+
+-- name: RecordReadingProgress :execrows
+-- Saves how far through an article a reader is, unless their history is
+-- paused. The article counts as finished once progress reaches
+-- finished_progress and stays finished when they scroll back.
+INSERT INTO article_reads AS ar (user_id, article_id, progress, finished_at)
+SELECT u.id, sqlc.arg(article_id), sqlc.arg(progress)::real,
+    (CASE WHEN sqlc.arg(progress)::real >= sqlc.arg(finished_progress)::real THEN NOW() END)::timestamp
+FROM users u
+WHERE u.id = sqlc.arg(user_id) AND NOT u.history_paused
+ON CONFLICT (user_id, article_id) DO UPDATE
+SET progress = EXCLUDED.progress, read_at = NOW(),
+    finished_at = COALESCE(ar.finished_at, EXCLUDED.finished_at);
+
+-- name: RecordUserRead :exec
+-- Marks an article finished when the read beacon counts a read, unless the
+-- reader's history is paused
+INSERT INTO article_reads AS ar (user_id, article_id, finished_at)
+SELECT u.id, sqlc.arg(article_id), NOW() FROM users u
+WHERE u.id = sqlc.arg(user_id) AND NOT u.history_paused
+ON CONFLICT (user_id, article_id) DO UPDATE
+SET read_at = NOW(), finished_at = COALESCE(ar.finished_at, NOW());
+
+-- name: GetReadingPosition :one
+SELECT progress, finished_at, read_at FROM article_reads
+WHERE user_id = $1 AND article_id = $2;
+
+-- name: ListReadingHistory :many
+-- Published articles a reader has opened, most recently opened first
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN sqlc.arg(include_body)::bool THEN a.body ELSE '' END)::text AS body,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug,
+    r.progress,
+    r.finished_at,
+    r.first_opened_at,
+    r.read_at AS last_read_at
+FROM article_reads r
+JOIN articles a ON r.article_id = a.id
+JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE r.user_id = sqlc.arg(user_id) AND a.status = 'published'
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (r.read_at, a.id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid))
+ORDER BY r.read_at DESC, a.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ClearReadingHistory :execrows
+DELETE FROM article_reads
+WHERE user_id = $1;
+
+-- name: DeleteReadingHistoryItem :execrows
+DELETE FROM article_reads
+WHERE user_id = $1 AND article_id = $2;
+
+-- name: GetHistoryPaused :one
+SELECT history_paused FROM users
+WHERE id = $1;
+
+-- name: SetHistoryPaused :exec
+UPDATE users
+SET history_paused = $2, updated_at = NOW()
+WHERE id = $1;
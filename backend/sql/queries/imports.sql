@@ -0,0 +1,45 @@
+-- name: CreateImportJob :one
+INSERT INTO import_jobs (user_id, source, dry_run)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetImportJob :one
+SELECT * FROM import_jobs
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListImportJobs :many
+SELECT * FROM import_jobs
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 20;
+
+-- name: StartImportJob :exec
+UPDATE import_jobs
+SET status = 'running'
+WHERE id = $1;
+
+-- name: FinishImportJob :exec
+UPDATE import_jobs
+SET status = $2, report = $3, error = $4, finished_at = NOW()
+WHERE id = $1;
+
+-- name: FailInterruptedImportJobs :execrows
+-- Jobs run in the server process, so any still pending or running when it
+-- starts were cut off by a restart
+UPDATE import_jobs
+SET status = 'failed', error = 'Import was interrupted by a server restart; please upload the file again',
+    finished_at = NOW()
+WHERE status IN ('pending', 'running');
+
+-- name: ListImportKeys :many
+-- Import keys the user has already imported, out of the given ones
+SELECT import_key::text FROM articles
+WHERE user_id = sqlc.arg(user_id) AND import_key = ANY(sqlc.arg(import_keys)::text[]);
+
+-- name: CreateImportedArticle :one
+-- Unlike CreateArticle and PublishArticle, keeps the original publish date
+INSERT INTO articles (user_id, title, body, summary, status, published_at, import_key,
+    canonical_url, original_published_at, language)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (user_id, import_key) WHERE import_key IS NOT NULL DO NOTHING
+RETURNING *;
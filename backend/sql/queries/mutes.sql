@@ -0,0 +1,51 @@
+-- name: MuteAuthor :exec
+INSERT INTO muted_authors (user_id, muted_user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: UnmuteAuthor :execrows
+DELETE FROM muted_authors
+WHERE user_id = $1 AND muted_user_id = $2;
+
+-- name: ListMutedAuthors :many
+SELECT u.id, u.username, u.name, u.avatar_url, ma.created_at AS muted_at
+FROM muted_authors ma
+JOIN users u ON u.id = ma.muted_user_id
+WHERE ma.user_id = $1
+ORDER BY ma.created_at DESC;
+
+-- name: MuteTag :exec
+INSERT INTO muted_tags (user_id, tag_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: UnmuteTag :execrows
+DELETE FROM muted_tags mt
+USING tags t
+WHERE mt.tag_id = t.id AND mt.user_id = sqlc.arg(user_id) AND t.name = LOWER(sqlc.arg(tag_name));
+
+-- name: ListMutedTags :many
+SELECT t.name, mt.created_at AS muted_at
+FROM muted_tags mt
+JOIN tags t ON t.id = mt.tag_id
+WHERE mt.user_id = $1
+ORDER BY mt.created_at DESC;
+
+-- name: MuteKeyword :exec
+INSERT INTO muted_keywords (user_id, keyword)
+VALUES (sqlc.arg(user_id), LOWER(sqlc.arg(keyword)))
+ON CONFLICT DO NOTHING;
+
+-- name: UnmuteKeyword :execrows
+DELETE FROM muted_keywords
+WHERE user_id = sqlc.arg(user_id) AND keyword = LOWER(sqlc.arg(keyword));
+
+-- name: ListMutedKeywords :many
+SELECT keyword, created_at AS muted_at
+FROM muted_keywords
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: CountMutedKeywords :one
+SELECT COUNT(*)::int FROM muted_keywords
+WHERE user_id = $1;
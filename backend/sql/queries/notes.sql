@@ -0,0 +1,42 @@
+-- name: CreateArticleNote :one
+INSERT INTO article_notes (article_id, user_id, share_link_id, reviewer_name, body, anchor_start, anchor_end, quote)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetArticleNote :one
+SELECT sqlc.embed(n),
+    u.username AS author_username,
+    u.name AS author_name
+FROM article_notes n
+LEFT JOIN users u ON n.user_id = u.id
+WHERE n.id = $1;
+
+-- name: ListArticleNotes :many
+SELECT sqlc.embed(n),
+    u.username AS author_username,
+    u.name AS author_name
+FROM article_notes n
+LEFT JOIN users u ON n.user_id = u.id
+WHERE n.article_id = $1
+ORDER BY n.detached ASC, n.anchor_start ASC, n.created_at ASC;
+
+-- name: SetArticleNoteResolved :one
+UPDATE article_notes
+SET resolved_at = CASE WHEN sqlc.arg(resolved)::bool THEN NOW() ELSE NULL END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteArticleNote :exec
+DELETE FROM article_notes
+WHERE id = $1;
+
+-- name: ListNoteAnchors :many
+SELECT id, anchor_start, anchor_end, quote
+FROM article_notes
+WHERE article_id = $1 AND NOT detached;
+
+-- name: UpdateNoteAnchor :exec
+UPDATE article_notes
+SET anchor_start = $2, anchor_end = $3, detached = $4, updated_at = NOW()
+WHERE id = $1;
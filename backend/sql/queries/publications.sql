@@ -0,0 +1,141 @@
+-- name: CreatePublication :one
+-- Creates a publication with its creator as owner in one statement
+WITH created AS (
+    INSERT INTO publications (slug, name, description, avatar_url)
+    VALUES (LOWER(sqlc.arg(slug)), sqlc.arg(name), sqlc.arg(description), sqlc.arg(avatar_url))
+    RETURNING *
+), owner AS (
+    INSERT INTO publication_members (publication_id, user_id, role)
+    SELECT c.id, sqlc.arg(owner_id), 'owner' FROM created c
+)
+SELECT * FROM created;
+
+-- name: GetPublicationBySlug :one
+SELECT * FROM publications
+WHERE slug = LOWER(sqlc.arg(slug));
+
+-- name: UpdatePublication :one
+UPDATE publications
+SET name = $2, description = $3, avatar_url = $4, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: AddPublicationMember :exec
+INSERT INTO publication_members (publication_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (publication_id, user_id) DO UPDATE
+SET role = EXCLUDED.role;
+
+-- name: RemovePublicationMember :exec
+DELETE FROM publication_members
+WHERE publication_id = $1 AND user_id = $2;
+
+-- name: GetPublicationMemberRole :one
+SELECT role FROM publication_members
+WHERE publication_id = $1 AND user_id = $2;
+
+-- name: ListPublicationMembers :many
+SELECT u.id, u.username, u.name, u.avatar_url, pm.role, pm.created_at
+FROM publication_members pm
+JOIN users u ON pm.user_id = u.id
+WHERE pm.publication_id = $1
+ORDER BY pm.created_at ASC;
+
+-- name: FollowPublication :exec
+INSERT INTO publication_follows (follower_id, publication_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: UnfollowPublication :exec
+DELETE FROM publication_follows
+WHERE follower_id = $1 AND publication_id = $2;
+
+-- name: CountPublicationFollowers :one
+SELECT COUNT(*)::int FROM publication_follows
+WHERE publication_id = $1;
+
+-- name: ListArticlesByPublication :many
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN sqlc.arg(include_body)::bool THEN a.body ELSE '' END)::text AS body,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
+FROM articles a
+JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE a.publication_id = sqlc.arg(publication_id) AND a.status = 'published'
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (a.published_at, a.id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid))
+ORDER BY a.published_at DESC, a.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: UpsertSubmission :one
+INSERT INTO publication_submissions (publication_id, article_id, submitted_by, note)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (publication_id, article_id) DO UPDATE
+SET status = 'pending', note = EXCLUDED.note, submitted_by = EXCLUDED.submitted_by, updated_at = NOW()
+WHERE publication_submissions.status IN ('pending', 'changes_requested')
+RETURNING *;
+
+-- name: HasOpenSubmission :one
+-- Whether an article is in a publication's review or accepted and waiting for
+-- its editors to publish it
+SELECT EXISTS (
+    SELECT 1 FROM publication_submissions
+    WHERE article_id = $1 AND status IN ('pending', 'changes_requested', 'accepted')
+)::bool;
+
+-- name: GetSubmissionByID :one
+SELECT * FROM publication_submissions
+WHERE id = $1;
+
+-- name: ListPublicationSubmissions :many
+SELECT s.*,
+    a.title AS article_title,
+    u.username AS submitter_username,
+    u.name AS submitter_name
+FROM publication_submissions s
+JOIN articles a ON s.article_id = a.id
+JOIN users u ON s.submitted_by = u.id
+WHERE s.publication_id = sqlc.arg(publication_id)
+    AND (sqlc.arg(status)::text = '' OR s.status = sqlc.arg(status)::text)
+    AND (sqlc.narg(submitted_by)::uuid IS NULL OR s.submitted_by = sqlc.narg(submitted_by)::uuid)
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (s.updated_at, s.id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid))
+ORDER BY s.updated_at DESC, s.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: UpdateSubmissionStatus :one
+-- Moves a submission on from expected_status; nothing is returned when another
+-- review got there first.
+UPDATE publication_submissions
+SET status = sqlc.arg(status), reviewer_id = sqlc.arg(reviewer_id), review_note = sqlc.arg(review_note),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(expected_status)
+RETURNING *;
+
+-- name: AcceptSubmission :one
+-- Accepts a pending submission and attaches its article to the publication in
+-- one statement. Nothing is returned when the submission is no longer pending
+-- or the article is no longer a draft outside any publication.
+WITH pending AS (
+    SELECT s.id, s.publication_id, s.article_id FROM publication_submissions s
+    WHERE s.id = sqlc.arg(id) AND s.status = 'pending'
+    FOR UPDATE
+), attached AS (
+    UPDATE articles a
+    SET publication_id = p.publication_id, updated_at = NOW()
+    FROM pending p
+    WHERE a.id = p.article_id AND a.status = 'draft' AND a.publication_id IS NULL
+    RETURNING a.id
+)
+UPDATE publication_submissions s
+SET status = 'accepted', reviewer_id = sqlc.arg(reviewer_id), updated_at = NOW()
+FROM attached t
+WHERE s.id = sqlc.arg(id) AND s.article_id = t.id
+RETURNING s.*;
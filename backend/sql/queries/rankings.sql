@@ -0,0 +1,74 @@
+-- name: RefreshArticleScores :exec
+-- Scores every article published within each window as a new generation
+-- stamped with computed_at. Engagement points come from the window's daily
+-- rollups and weigh new clappers and comments above raw claps, plus reads and
+-- views. top is the points; trending divides them by the article's age in
+-- hours raised to a gravity of 1.8, so fresh articles rise and fade.
+WITH windows AS (
+    SELECT 'day'::text AS name, INTERVAL '1 day' AS span
+    UNION ALL SELECT 'week', INTERVAL '7 days'
+    UNION ALL SELECT 'month', INTERVAL '30 days'
+), points AS (
+    SELECT a.id, w.name AS time_window,
+        EXTRACT(EPOCH FROM (NOW() - a.published_at)) / 3600 AS age_hours,
+        COALESCE((
+            SELECT SUM(s.claps + 3 * s.clappers + 4 * s.comments + 0.5 * s.reads + 0.1 * s.views)
+            FROM article_daily_stats s
+            WHERE s.article_id = a.id AND s.day >= (NOW() - w.span)::date
+        ), 0) AS points
+    FROM articles a
+    CROSS JOIN windows w
+    WHERE a.status = 'published' AND a.published_at >= NOW() - w.span
+)
+INSERT INTO article_scores (article_id, time_window, trending, top, computed_at)
+SELECT p.id, p.time_window,
+    p.points / POWER(GREATEST(p.age_hours, 0) + 2, 1.8),
+    p.points,
+    sqlc.arg(computed_at)::timestamp
+FROM points p;
+
+-- name: DeleteStaleArticleScores :exec
+-- Removes generations older than the one before computed_at, which is kept
+-- for readers still paging through it
+DELETE FROM article_scores
+WHERE computed_at < COALESCE((
+    SELECT MAX(s.computed_at) FROM article_scores s
+    WHERE s.computed_at < sqlc.arg(computed_at)::timestamp
+), sqlc.arg(computed_at)::timestamp);
+
+-- name: ListRankedArticles :many
+-- Published articles ordered by a precomputed score, optionally within a tag.
+-- Pages after the first read the generation of scores named by the cursor,
+-- or the latest one once it has been deleted.
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN sqlc.arg(include_body)::bool THEN a.body ELSE '' END)::text AS body,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug,
+    (CASE WHEN sqlc.arg(sort)::text = 'top' THEN s.top ELSE s.trending END)::float8 AS score,
+    s.computed_at AS scores_computed_at
+FROM article_scores s
+JOIN articles a ON a.id = s.article_id
+JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE s.time_window = sqlc.arg(time_window) AND a.status = 'published'
+    AND s.computed_at = COALESCE(
+        (SELECT g.computed_at FROM article_scores g WHERE g.computed_at = sqlc.narg(cursor_computed_at)::timestamp LIMIT 1),
+        (SELECT MAX(g.computed_at) FROM article_scores g)
+    )
+    AND NOT article_muted(sqlc.narg(viewer_id)::uuid, a.id, a.user_id, a.title, a.summary)
+    AND (sqlc.narg(tag_name)::text IS NULL OR EXISTS (
+        SELECT 1 FROM article_tags at
+        JOIN tags t ON at.tag_id = t.id
+        WHERE at.article_id = a.id AND t.name = LOWER(sqlc.narg(tag_name))
+    ))
+    AND (sqlc.narg(cursor_rank)::float8 IS NULL
+        OR ((CASE WHEN sqlc.arg(sort)::text = 'top' THEN s.top ELSE s.trending END), a.id)
+            < (sqlc.narg(cursor_rank)::float8, sqlc.narg(cursor_id)::uuid))
+ORDER BY score DESC, a.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,101 @@
+-- name: GetDefaultReadingList :one
+-- Returns the user's default list, creating it on first use
+WITH created AS (
+    INSERT INTO reading_lists (user_id, name, is_default)
+    VALUES (sqlc.arg(user_id), 'Reading list', true)
+    ON CONFLICT (user_id) WHERE is_default DO NOTHING
+    RETURNING *
+)
+SELECT c.* FROM created c
+UNION ALL
+SELECT l.* FROM reading_lists l
+WHERE l.user_id = sqlc.arg(user_id) AND l.is_default
+LIMIT 1;
+
+-- name: CreateReadingList :one
+INSERT INTO reading_lists (user_id, name, description, is_public)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetReadingList :one
+SELECT sqlc.embed(l),
+    u.username AS owner_username,
+    (SELECT COUNT(*) FROM reading_list_items i WHERE i.list_id = l.id)::int AS item_count
+FROM reading_lists l
+JOIN users u ON l.user_id = u.id
+WHERE l.id = $1;
+
+-- name: ListReadingLists :many
+SELECT sqlc.embed(l),
+    (SELECT COUNT(*) FROM reading_list_items i WHERE i.list_id = l.id)::int AS item_count
+FROM reading_lists l
+WHERE l.user_id = $1
+ORDER BY l.is_default DESC, l.created_at ASC;
+
+-- name: ListPublicReadingLists :many
+SELECT sqlc.embed(l),
+    (SELECT COUNT(*) FROM reading_list_items i WHERE i.list_id = l.id)::int AS item_count
+FROM reading_lists l
+JOIN users u ON l.user_id = u.id
+WHERE u.username = $1 AND l.is_public
+ORDER BY l.is_default DESC, l.created_at ASC;
+
+-- name: UpdateReadingList :one
+UPDATE reading_lists
+SET name = $3, description = $4, is_public = $5, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteReadingList :execrows
+-- The default list cannot be deleted
+DELETE FROM reading_lists
+WHERE id = $1 AND user_id = $2 AND NOT is_default;
+
+-- name: AddReadingListItem :execrows
+-- Appends an article to the end of a list; adding it again does nothing
+INSERT INTO reading_list_items (list_id, article_id, position)
+SELECT sqlc.arg(list_id), sqlc.arg(article_id), COALESCE(MAX(i.position), 0) + 1
+FROM reading_list_items i
+WHERE i.list_id = sqlc.arg(list_id)
+ON CONFLICT (list_id, article_id) DO NOTHING;
+
+-- name: RemoveReadingListItem :execrows
+DELETE FROM reading_list_items
+WHERE list_id = $1 AND article_id = $2;
+
+-- name: ReorderReadingListItems :execrows
+-- Positions the listed articles in the order given
+UPDATE reading_list_items
+SET position = array_position(sqlc.arg(article_ids)::uuid[], article_id)
+WHERE list_id = sqlc.arg(list_id) AND article_id = ANY(sqlc.arg(article_ids)::uuid[]);
+
+-- name: ListReadingListArticles :many
+-- Articles in a list in order. Only published articles can be added.
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN sqlc.arg(include_body)::bool THEN a.body ELSE '' END)::text AS body,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug,
+    i.position,
+    i.added_at
+FROM reading_list_items i
+JOIN articles a ON i.article_id = a.id
+JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
+WHERE i.list_id = sqlc.arg(list_id)
+    AND (sqlc.narg(cursor_id)::uuid IS NULL
+        OR (i.position, a.id) > (sqlc.narg(cursor_position)::int, sqlc.narg(cursor_id)::uuid))
+ORDER BY i.position ASC, a.id ASC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListBookmarkedArticleIDs :many
+-- Which of the given articles are in any of the user's lists
+SELECT DISTINCT i.article_id
+FROM reading_list_items i
+JOIN reading_lists l ON i.list_id = l.id
+WHERE l.user_id = sqlc.arg(user_id) AND i.article_id = ANY(sqlc.arg(article_ids)::uuid[]);
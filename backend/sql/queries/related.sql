@@ -0,0 +1,77 @@
+-- name: GetRelatedArticleCache :one
+SELECT related_ids FROM article_related_cache
+WHERE article_id = $1 AND computed_at > NOW() - INTERVAL '1 day';
+
+-- name: SetRelatedArticleCache :exec
+INSERT INTO article_related_cache (article_id, related_ids)
+VALUES (sqlc.arg(article_id), sqlc.arg(related_ids)::uuid[])
+ON CONFLICT (article_id) DO UPDATE
+SET related_ids = EXCLUDED.related_ids, computed_at = NOW();
+
+-- name: InvalidateRelatedArticleCache :exec
+-- Drops the cached lists of an article, its author's articles and articles
+-- sharing a tag with it: the ones a newly published article may belong in
+DELETE FROM article_related_cache c
+WHERE c.article_id = sqlc.arg(article_id)
+    OR c.article_id IN (
+        SELECT o.article_id FROM article_tags o
+        JOIN article_tags t ON t.tag_id = o.tag_id
+        WHERE t.article_id = sqlc.arg(article_id)
+    )
+    OR c.article_id IN (
+        SELECT a.id FROM articles a
+        WHERE a.user_id = (SELECT s.user_id FROM articles s WHERE s.id = sqlc.arg(article_id))
+    );
+
+-- name: ComputeRelatedArticles :many
+-- Ranks published articles by shared tags, same author and text similarity
+-- to an article. Similarity matches the article's 20 most frequent lexemes
+-- against each candidate's search_vector.
+WITH source AS (
+    SELECT s.id, s.user_id, s.search_vector FROM articles s
+    WHERE s.id = sqlc.arg(article_id)
+), terms AS (
+    SELECT COALESCE(string_agg(quote_literal(l.lexeme), ' | '), '')::tsquery AS query
+    FROM (
+        SELECT u.lexeme FROM source src, unnest(src.search_vector) AS u(lexeme, positions, weights)
+        ORDER BY COALESCE(array_length(u.positions, 1), 0) DESC, u.lexeme
+        LIMIT 20
+    ) l
+), scored AS (
+    SELECT a.id,
+        (SELECT COUNT(*) FROM article_tags at
+            WHERE at.article_id = a.id
+                AND at.tag_id IN (SELECT st.tag_id FROM article_tags st WHERE st.article_id = sqlc.arg(article_id))
+        ) AS shared_tags,
+        (a.user_id = src.user_id) AS same_author,
+        ts_rank(a.search_vector, terms.query) AS similarity
+    FROM articles a, source src, terms
+    WHERE a.status = 'published' AND a.id <> src.id
+)
+SELECT sc.id FROM scored sc
+WHERE sc.shared_tags > 0 OR sc.same_author OR sc.similarity > 0
+ORDER BY (3 * sc.shared_tags + (CASE WHEN sc.same_author THEN 2 ELSE 0 END) + 10 * sc.similarity) DESC, sc.id
+LIMIT sqlc.arg('limit');
+
+-- name: ListRelatedArticles :many
+-- Loads cached related articles in order. Articles the viewer has finished
+-- are listed as usual, last or not at all when finished is show, last or hide.
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    u.username AS author_username,
+    u.name AS author_name,
+    u.avatar_url AS author_avatar_url,
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
+FROM articles a
+JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
+LEFT JOIN article_reads r ON r.article_id = a.id
+    AND r.user_id = sqlc.narg(viewer_id)::uuid AND r.finished_at IS NOT NULL
+WHERE a.id = ANY(sqlc.arg(ids)::uuid[]) AND a.status = 'published'
+    AND (sqlc.arg(finished)::text <> 'hide' OR r.article_id IS NULL)
+ORDER BY (CASE WHEN sqlc.arg(finished)::text = 'last' AND r.article_id IS NOT NULL THEN 1 ELSE 0 END),
+    array_position(sqlc.arg(ids)::uuid[], a.id)
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,23 @@
+-- name: CreateShareLink :one
+INSERT INTO article_share_links (article_id, created_by, label, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListShareLinks :many
+SELECT l.*,
+    u.username AS creator_username
+FROM article_share_links l
+JOIN users u ON l.created_by = u.id
+WHERE l.article_id = $1
+ORDER BY l.created_at DESC;
+
+-- name: GetActiveShareLink :one
+SELECT * FROM article_share_links
+WHERE id = $1
+    AND revoked_at IS NULL
+    AND (expires_at IS NULL OR expires_at > NOW());
+
+-- name: RevokeShareLink :execrows
+UPDATE article_share_links
+SET revoked_at = NOW()
+WHERE id = $1 AND article_id = $2 AND revoked_at IS NULL;
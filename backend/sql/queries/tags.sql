@@ -31,16 +31,32 @@ JOIN article_tags at ON t.id = at.tag_id
 WHERE at.article_id = $1
 ORDER BY t.name;
 
+-- name: GetTagsForArticles :many
+SELECT at.article_id, sqlc.embed(t)
+FROM article_tags at
+JOIN tags t ON t.id = at.tag_id
+WHERE at.article_id = ANY(sqlc.arg(article_ids)::uuid[])
+ORDER BY at.article_id, t.name;
+
 -- name: ListArticlesByTag :many
-SELECT a.*,
+SELECT a.id, a.user_id, a.title, a.summary, a.thumbnail_url, a.status, a.published_at,
+    a.created_at, a.updated_at, a.publication_id, a.revision, a.reading_time, a.comment_count, a.visibility,
+    a.canonical_url, a.original_published_at, a.language,
+    (CASE WHEN sqlc.arg(include_body)::bool THEN a.body ELSE '' END)::text AS body,
     u.username AS author_username,
     u.name AS author_name,
     u.avatar_url AS author_avatar_url,
-    COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0)::int AS total_claps
+    a.clap_count AS total_claps,
+    p.name AS publication_name,
+    p.slug AS publication_slug
 FROM articles a
 JOIN users u ON a.user_id = u.id
+LEFT JOIN publications p ON a.publication_id = p.id
 JOIN article_tags at ON a.id = at.article_id
 JOIN tags t ON at.tag_id = t.id
-WHERE t.name = LOWER($1) AND a.status = 'published'
-ORDER BY a.published_at DESC
-LIMIT $2 OFFSET $3;
+WHERE t.name = LOWER(sqlc.arg(tag_name)) AND a.status = 'published'
+    AND NOT article_muted(sqlc.narg(viewer_id)::uuid, a.id, a.user_id, a.title, a.summary)
+    AND (sqlc.narg(cursor_time)::timestamp IS NULL
+        OR (a.published_at, a.id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::uuid))
+ORDER BY a.published_at DESC, a.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,39 @@
+-- name: ListArticleTranslations :many
+-- Other articles in an article's translation group. Drafts are only listed
+-- when include_drafts is true.
+SELECT t.id, t.user_id, t.title, t.language, t.status, t.slug, t.published_at
+FROM articles a
+JOIN articles t ON t.translation_group_id = a.translation_group_id AND t.id <> a.id
+WHERE a.id = sqlc.arg(id) AND (sqlc.arg(include_drafts)::bool OR t.status = 'published')
+ORDER BY t.language, t.id;
+
+-- name: LinkArticleTranslation :exec
+-- Puts two articles, along with any translations either already has, in one
+-- translation group
+WITH target AS (
+    SELECT COALESCE(s.translation_group_id, o.translation_group_id, gen_random_uuid()) AS group_id
+    FROM articles s, articles o
+    WHERE s.id = sqlc.arg(article_id) AND o.id = sqlc.arg(translation_id)
+)
+UPDATE articles a
+SET translation_group_id = target.group_id
+FROM target
+WHERE a.id IN (sqlc.arg(article_id), sqlc.arg(translation_id))
+    OR a.translation_group_id IN (
+        SELECT g.translation_group_id FROM articles g
+        WHERE g.id IN (sqlc.arg(article_id), sqlc.arg(translation_id)) AND g.translation_group_id IS NOT NULL
+    );
+
+-- name: UnlinkArticleTranslation :execrows
+-- Removes an article from its translation group, dissolving the group when
+-- a single article would be left in it
+WITH source AS (
+    SELECT s.translation_group_id AS group_id FROM articles s
+    WHERE s.id = sqlc.arg(id)
+)
+UPDATE articles a
+SET translation_group_id = NULL
+FROM source
+WHERE a.translation_group_id = source.group_id
+    AND (a.id = sqlc.arg(id)
+        OR (SELECT COUNT(*) FROM articles c WHERE c.translation_group_id = source.group_id) <= 2);
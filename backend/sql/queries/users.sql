@@ -24,10 +24,31 @@ SELECT * FROM users
 WHERE username = $1;
 
 -- name: UpdateUserProfile :one
+-- A zero expected_revision skips the optimistic concurrency check.
 UPDATE users
-SET name = $2, bio = $3, avatar_url = $4, updated_at = NOW()
-WHERE id = $1
+SET name = sqlc.arg(name), bio = sqlc.arg(bio), avatar_url = sqlc.arg(avatar_url),
+    revision = revision + 1, updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND (sqlc.arg(expected_revision)::int = 0 OR revision = sqlc.arg(expected_revision)::int)
+RETURNING *;
+
+-- name: PatchUserProfile :one
+-- Only non-null arguments are written; the rest keep their current values.
+UPDATE users
+SET name = COALESCE(sqlc.narg(name), name),
+    bio = COALESCE(sqlc.narg(bio), bio),
+    avatar_url = COALESCE(sqlc.narg(avatar_url), avatar_url),
+    revision = revision + 1,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+    AND (sqlc.arg(expected_revision)::int = 0 OR revision = sqlc.arg(expected_revision)::int)
 RETURNING *;
 
 -- name: DeleteUsers :exec
 DELETE FROM users;
+
+-- name: SetUserMembership :one
+UPDATE users
+SET is_member = sqlc.arg(is_member), updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE article_collaborators (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('co-author', 'editor', 'viewer')),
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    accepted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (article_id, user_id)
+);
+
+CREATE INDEX idx_article_collaborators_user_id ON article_collaborators(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS article_collaborators;
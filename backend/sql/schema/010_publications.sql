@@ -0,0 +1,57 @@
+-- +goose Up
+CREATE TABLE publications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug VARCHAR(60) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    avatar_url TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE publication_members (
+    publication_id UUID NOT NULL REFERENCES publications(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'editor', 'writer')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (publication_id, user_id)
+);
+
+CREATE INDEX idx_publication_members_user_id ON publication_members(user_id);
+
+CREATE TABLE publication_submissions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    publication_id UUID NOT NULL REFERENCES publications(id) ON DELETE CASCADE,
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    submitted_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'changes_requested', 'accepted', 'published')),
+    note TEXT NOT NULL DEFAULT '',
+    reviewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    review_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (publication_id, article_id)
+);
+
+CREATE INDEX idx_publication_submissions_article_id ON publication_submissions(article_id);
+
+CREATE TABLE publication_follows (
+    follower_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    publication_id UUID NOT NULL REFERENCES publications(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, publication_id)
+);
+
+CREATE INDEX idx_publication_follows_publication_id ON publication_follows(publication_id);
+
+ALTER TABLE articles ADD COLUMN publication_id UUID REFERENCES publications(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_articles_publication_id ON articles(publication_id);
+
+-- +goose Down
+ALTER TABLE articles DROP COLUMN IF EXISTS publication_id;
+DROP TABLE IF EXISTS publication_follows;
+DROP TABLE IF EXISTS publication_submissions;
+DROP TABLE IF EXISTS publication_members;
+DROP TABLE IF EXISTS publications;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE articles ADD COLUMN revision INT NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN revision INT NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS revision;
+ALTER TABLE articles DROP COLUMN IF EXISTS revision;
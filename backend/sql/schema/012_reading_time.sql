@@ -0,0 +1,9 @@
+-- +goose Up
+-- Estimated reading time in minutes at ~250 words per minute
+ALTER TABLE articles ADD COLUMN reading_time INT NOT NULL
+    GENERATED ALWAYS AS (
+        GREATEST(1, CEIL(COALESCE(array_length(regexp_split_to_array(btrim(body), '\s+'), 1), 0) / 250.0))::int
+    ) STORED;
+
+-- +goose Down
+ALTER TABLE articles DROP COLUMN IF EXISTS reading_time;
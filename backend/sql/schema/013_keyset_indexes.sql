@@ -0,0 +1,14 @@
+-- +goose Up
+-- Composite indexes matching the (sort key, id) order used by cursor pagination
+CREATE INDEX idx_articles_published_keyset ON articles(published_at DESC, id DESC) WHERE status = 'published';
+CREATE INDEX idx_articles_updated_keyset ON articles(updated_at DESC, id DESC);
+CREATE INDEX idx_comments_article_keyset ON comments(article_id, created_at, id);
+CREATE INDEX idx_follows_following_keyset ON follows(following_id, created_at DESC);
+CREATE INDEX idx_follows_follower_keyset ON follows(follower_id, created_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_follows_follower_keyset;
+DROP INDEX IF EXISTS idx_follows_following_keyset;
+DROP INDEX IF EXISTS idx_comments_article_keyset;
+DROP INDEX IF EXISTS idx_articles_updated_keyset;
+DROP INDEX IF EXISTS idx_articles_published_keyset;
@@ -0,0 +1,32 @@
+-- +goose Up
+-- Denormalized engagement counters. The clap, comment and follow write
+-- queries keep them current; POST /api/admin/reconcile-counters repairs drift.
+ALTER TABLE articles
+    ADD COLUMN clap_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN clapper_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN comment_count INT NOT NULL DEFAULT 0;
+
+ALTER TABLE users
+    ADD COLUMN follower_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN following_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE articles a SET
+    clap_count = COALESCE((SELECT SUM(c.count) FROM claps c WHERE c.article_id = a.id), 0),
+    clapper_count = (SELECT COUNT(*) FROM claps c WHERE c.article_id = a.id),
+    comment_count = (SELECT COUNT(*) FROM comments c WHERE c.article_id = a.id);
+
+UPDATE users u SET
+    follower_count = (SELECT COUNT(*) FROM follows f WHERE f.following_id = u.id),
+    following_count = (SELECT COUNT(*) FROM follows f WHERE f.follower_id = u.id);
+
+-- +goose Down
+ALTER TABLE users
+    DROP COLUMN IF EXISTS is_admin,
+    DROP COLUMN IF EXISTS following_count,
+    DROP COLUMN IF EXISTS follower_count;
+
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS comment_count,
+    DROP COLUMN IF EXISTS clapper_count,
+    DROP COLUMN IF EXISTS clap_count;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Members-only articles show non-members a preview; membership is granted by admins
+ALTER TABLE articles ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'public'
+    CHECK (visibility IN ('public', 'members'));
+
+ALTER TABLE users ADD COLUMN is_member BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS is_member;
+ALTER TABLE articles DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Signed links that give read-only access to a draft without an account
+CREATE TABLE article_share_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    label TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_article_share_links_article_id ON article_share_links(article_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS article_share_links;
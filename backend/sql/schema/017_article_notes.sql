@@ -0,0 +1,25 @@
+-- +goose Up
+-- Private editorial notes anchored to a character range of a draft. Notes are
+-- written by collaborators or by share link reviewers who have no account.
+CREATE TABLE article_notes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    share_link_id UUID REFERENCES article_share_links(id) ON DELETE CASCADE,
+    reviewer_name TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    anchor_start INT NOT NULL,
+    anchor_end INT NOT NULL,
+    quote TEXT NOT NULL,
+    detached BOOLEAN NOT NULL DEFAULT false,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (user_id IS NOT NULL OR share_link_id IS NOT NULL),
+    CHECK (anchor_start >= 0 AND anchor_end >= anchor_start)
+);
+
+CREATE INDEX idx_article_notes_article_id ON article_notes(article_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS article_notes;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Optional per-author slug used to match imported Markdown files to articles
+ALTER TABLE articles ADD COLUMN slug VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_articles_user_slug ON articles(user_id, slug) WHERE slug IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_articles_user_slug;
+ALTER TABLE articles DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- Background imports from other platforms' export files
+CREATE TABLE import_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('medium', 'wordpress')),
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    report JSONB NOT NULL DEFAULT '{}',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_import_jobs_user_id ON import_jobs(user_id, created_at DESC);
+
+-- Source identifier of an imported post, so importing the same export twice skips it
+ALTER TABLE articles ADD COLUMN import_key TEXT;
+
+CREATE UNIQUE INDEX idx_articles_user_import_key ON articles(user_id, import_key) WHERE import_key IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_articles_user_import_key;
+ALTER TABLE articles DROP COLUMN IF EXISTS import_key;
+DROP TABLE IF EXISTS import_jobs;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Cross-post metadata: where an article first appeared and when
+ALTER TABLE articles ADD COLUMN canonical_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE articles ADD COLUMN original_published_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE articles DROP COLUMN IF EXISTS original_published_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS canonical_url;
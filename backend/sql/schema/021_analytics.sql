@@ -0,0 +1,46 @@
+-- +goose Up
+-- One row per viewer, article and UTC day. viewer_hash is a keyed hash salted
+-- with the day, so raw IPs are never stored and viewers can't be followed
+-- across days.
+CREATE TABLE article_views (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    viewer_hash TEXT NOT NULL,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (article_id, day, viewer_hash)
+);
+
+-- Daily rollups read by the stats endpoints
+CREATE TABLE article_daily_stats (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views INT NOT NULL DEFAULT 0,
+    reads INT NOT NULL DEFAULT 0,
+    claps INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (article_id, day)
+);
+
+CREATE TABLE user_daily_stats (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    followers_gained INT NOT NULL DEFAULT 0,
+    followers_lost INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, day)
+);
+
+-- Backfill claps and followers from the days they were first recorded
+INSERT INTO article_daily_stats (article_id, day, claps)
+SELECT article_id, created_at::date, SUM(count)
+FROM claps
+GROUP BY article_id, created_at::date;
+
+INSERT INTO user_daily_stats (user_id, day, followers_gained)
+SELECT following_id, created_at::date, COUNT(*)
+FROM follows
+GROUP BY following_id, created_at::date;
+
+-- +goose Down
+DROP TABLE IF EXISTS user_daily_stats;
+DROP TABLE IF EXISTS article_daily_stats;
+DROP TABLE IF EXISTS article_views;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Precomputed ranking scores for sort=trending and sort=top, refreshed
+-- periodically. An article has a row per window it was published within.
+CREATE TABLE article_scores (
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    time_window VARCHAR(10) NOT NULL CHECK (time_window IN ('day', 'week', 'month')),
+    trending DOUBLE PRECISION NOT NULL,
+    top DOUBLE PRECISION NOT NULL,
+    computed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (article_id, time_window)
+);
+
+CREATE INDEX idx_article_scores_trending ON article_scores(time_window, trending DESC, article_id DESC);
+CREATE INDEX idx_article_scores_top ON article_scores(time_window, top DESC, article_id DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS article_scores;
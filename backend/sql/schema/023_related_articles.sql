@@ -0,0 +1,20 @@
+-- +goose Up
+-- Articles a signed-in user has read, recorded by the read beacon
+CREATE TABLE article_reads (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    read_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, article_id)
+);
+
+-- Cached related article IDs per article, best match first. Entries are
+-- dropped when a related article is published and expire after a day.
+CREATE TABLE article_related_cache (
+    article_id UUID PRIMARY KEY REFERENCES articles(id) ON DELETE CASCADE,
+    related_ids UUID[] NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS article_related_cache;
+DROP TABLE IF EXISTS article_reads;
@@ -0,0 +1,59 @@
+-- +goose Up
+-- The language an article is written in, as an ISO 639-1 code
+ALTER TABLE articles ADD COLUMN language VARCHAR(10) NOT NULL DEFAULT 'en'
+    CHECK (language IN ('en', 'es', 'de', 'fr', 'pt', 'it', 'nl'));
+
+-- Text search configuration for an article language; unknown codes fall
+-- back to 'simple', which does no stemming
+-- +goose StatementBegin
+CREATE FUNCTION article_regconfig(lang TEXT) RETURNS regconfig AS $$
+    SELECT CASE lang
+        WHEN 'en' THEN 'english'
+        WHEN 'es' THEN 'spanish'
+        WHEN 'de' THEN 'german'
+        WHEN 'fr' THEN 'french'
+        WHEN 'pt' THEN 'portuguese'
+        WHEN 'it' THEN 'italian'
+        WHEN 'nl' THEN 'dutch'
+        ELSE 'simple'
+    END::regconfig
+$$ LANGUAGE SQL IMMUTABLE STRICT;
+-- +goose StatementEnd
+
+-- Search query for a language, or with no language one matching an article
+-- stemmed in any supported language
+-- +goose StatementBegin
+CREATE FUNCTION article_tsquery(lang TEXT, query TEXT) RETURNS tsquery AS $$
+    SELECT CASE WHEN lang IS NOT NULL THEN plainto_tsquery(article_regconfig(lang), query)
+        ELSE plainto_tsquery('english', query) || plainto_tsquery('spanish', query)
+            || plainto_tsquery('german', query) || plainto_tsquery('french', query)
+            || plainto_tsquery('portuguese', query) || plainto_tsquery('italian', query)
+            || plainto_tsquery('dutch', query)
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+-- +goose StatementEnd
+
+-- Re-index every article with its own language's stemming
+ALTER TABLE articles DROP COLUMN search_vector;
+ALTER TABLE articles ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector(article_regconfig(language), coalesce(title, '')), 'A') ||
+        setweight(to_tsvector(article_regconfig(language), coalesce(body, '')), 'B')
+    ) STORED;
+
+CREATE INDEX idx_articles_search ON articles USING GIN(search_vector);
+CREATE INDEX idx_articles_language ON articles(language);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_articles_language;
+ALTER TABLE articles DROP COLUMN search_vector;
+ALTER TABLE articles ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(body, '')), 'B')
+    ) STORED;
+CREATE INDEX idx_articles_search ON articles USING GIN(search_vector);
+
+DROP FUNCTION IF EXISTS article_tsquery(TEXT, TEXT);
+DROP FUNCTION IF EXISTS article_regconfig(TEXT);
+ALTER TABLE articles DROP COLUMN IF EXISTS language;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Articles sharing a translation group are translations of each other, at
+-- most one per language
+ALTER TABLE articles ADD COLUMN translation_group_id UUID;
+
+CREATE UNIQUE INDEX idx_articles_translation_language ON articles(translation_group_id, language)
+    WHERE translation_group_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_articles_translation_language;
+ALTER TABLE articles DROP COLUMN IF EXISTS translation_group_id;
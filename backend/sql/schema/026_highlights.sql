@@ -0,0 +1,22 @@
+-- +goose Up
+-- Readers' highlights of a character range of a published article, each with
+-- an optional note only its reader can see
+CREATE TABLE article_highlights (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    anchor_start INT NOT NULL,
+    anchor_end INT NOT NULL,
+    quote TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    detached BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (anchor_start >= 0 AND anchor_end > anchor_start)
+);
+
+CREATE INDEX idx_article_highlights_article_id ON article_highlights(article_id);
+CREATE INDEX idx_article_highlights_user_id ON article_highlights(user_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS article_highlights;
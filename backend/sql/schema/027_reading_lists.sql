@@ -0,0 +1,31 @@
+-- +goose Up
+-- Saved articles. Every reader has one default "Reading list", created on
+-- first use, and can add named lists that are private unless made public.
+CREATE TABLE reading_lists (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    is_public BOOLEAN NOT NULL DEFAULT false,
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE UNIQUE INDEX idx_reading_lists_default ON reading_lists(user_id) WHERE is_default;
+
+-- Articles in a list, ordered by ascending position
+CREATE TABLE reading_list_items (
+    list_id UUID NOT NULL REFERENCES reading_lists(id) ON DELETE CASCADE,
+    article_id UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list_id, article_id)
+);
+
+CREATE INDEX idx_reading_list_items_article_id ON reading_list_items(article_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS reading_list_items;
+DROP TABLE IF EXISTS reading_lists;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- article_reads becomes a reading history: every published article a
+-- signed-in reader opens, how far they got and whether they finished it.
+-- read_at is the last time the article was opened or read.
+ALTER TABLE article_reads ADD COLUMN first_opened_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE article_reads ADD COLUMN progress REAL NOT NULL DEFAULT 0
+    CHECK (progress >= 0 AND progress <= 1);
+ALTER TABLE article_reads ADD COLUMN finished_at TIMESTAMP;
+
+-- Rows recorded so far came from the read beacon, so they count as finished
+UPDATE article_reads SET first_opened_at = read_at, progress = 1, finished_at = read_at;
+
+CREATE INDEX idx_article_reads_history ON article_reads(user_id, read_at DESC, article_id DESC);
+
+-- Readers can pause history; nothing is recorded while it is paused
+ALTER TABLE users ADD COLUMN history_paused BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS history_paused;
+DROP INDEX IF EXISTS idx_article_reads_history;
+DELETE FROM article_reads WHERE finished_at IS NULL;
+ALTER TABLE article_reads DROP COLUMN IF EXISTS finished_at;
+ALTER TABLE article_reads DROP COLUMN IF EXISTS progress;
+ALTER TABLE article_reads DROP COLUMN IF EXISTS first_opened_at;
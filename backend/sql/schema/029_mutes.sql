@@ -0,0 +1,56 @@
+-- +goose Up
+-- Per-reader mute lists. Articles by muted authors, with muted tags or whose
+-- title or summary contains a muted keyword are left out of the reader's
+-- listings, feed and search results; comments by muted authors are collapsed.
+CREATE TABLE muted_authors (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, muted_user_id),
+    CHECK (user_id <> muted_user_id)
+);
+
+CREATE TABLE muted_tags (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, tag_id)
+);
+
+-- Keywords are stored lowercased and matched as case-insensitive substrings
+CREATE TABLE muted_keywords (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    keyword VARCHAR(100) NOT NULL CHECK (keyword = LOWER(keyword) AND keyword <> ''),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, keyword)
+);
+
+-- Whether a reader has muted an article's author, one of its tags or a
+-- keyword in its title or summary. Anonymous readers (NULL) mute nothing.
+-- +goose StatementBegin
+CREATE FUNCTION article_muted(viewer UUID, target_article UUID, target_author UUID,
+    target_title TEXT, target_summary TEXT) RETURNS BOOLEAN AS $$
+    SELECT viewer IS NOT NULL AND (
+        EXISTS (
+            SELECT 1 FROM muted_authors ma
+            WHERE ma.user_id = viewer AND ma.muted_user_id = target_author
+        ) OR EXISTS (
+            SELECT 1 FROM muted_tags mt
+            JOIN article_tags at ON at.tag_id = mt.tag_id
+            WHERE mt.user_id = viewer AND at.article_id = target_article
+        ) OR EXISTS (
+            SELECT 1 FROM muted_keywords mk
+            WHERE mk.user_id = viewer AND (
+                POSITION(mk.keyword IN LOWER(target_title)) > 0
+                OR POSITION(mk.keyword IN LOWER(target_summary)) > 0
+            )
+        )
+    )
+$$ LANGUAGE SQL STABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION IF EXISTS article_muted(UUID, UUID, UUID, TEXT, TEXT);
+DROP TABLE IF EXISTS muted_keywords;
+DROP TABLE IF EXISTS muted_tags;
+DROP TABLE IF EXISTS muted_authors;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Replies. depth is 0 for top-level comments and one more than the parent's
+-- for replies.
+ALTER TABLE comments ADD COLUMN parent_id UUID REFERENCES comments(id) ON DELETE CASCADE;
+ALTER TABLE comments ADD COLUMN depth INT NOT NULL DEFAULT 0;
+
+-- Deleting a comment that has replies blanks it instead, so the thread
+-- stays intact under a "[deleted]" placeholder
+ALTER TABLE comments ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_comments_parent_keyset ON comments(parent_id, created_at, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_comments_parent_keyset;
+ALTER TABLE comments DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE comments DROP COLUMN IF EXISTS depth;
+DELETE FROM comments WHERE deleted_at IS NOT NULL;
+ALTER TABLE comments DROP COLUMN IF EXISTS deleted_at;
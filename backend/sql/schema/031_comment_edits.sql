@@ -0,0 +1,18 @@
+-- +goose Up
+-- When a comment was last edited by its author, NULL if never
+ALTER TABLE comments ADD COLUMN edited_at TIMESTAMP;
+
+-- Earlier versions of edited comments, for moderators. replaced_at is when
+-- the version was replaced by an edit.
+CREATE TABLE comment_edits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id UUID NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    replaced_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_comment_edits_comment_id ON comment_edits(comment_id, replaced_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS comment_edits;
+ALTER TABLE comments DROP COLUMN IF EXISTS edited_at;
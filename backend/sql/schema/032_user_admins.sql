@@ -0,0 +1,9 @@
+-- +goose Up
+-- Administrators can run maintenance endpoints such as
+-- POST /api/admin/reconcile-counters
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE users
+    DROP COLUMN IF EXISTS is_admin;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- New clappers and comments per article and day, so rankings can weigh only
+-- the engagement inside their window
+ALTER TABLE article_daily_stats
+    ADD COLUMN clappers INT NOT NULL DEFAULT 0,
+    ADD COLUMN comments INT NOT NULL DEFAULT 0;
+
+-- Backfill from the days readers first clapped and commented
+INSERT INTO article_daily_stats AS s (article_id, day, clappers)
+SELECT article_id, created_at::date, COUNT(*)
+FROM claps
+GROUP BY article_id, created_at::date
+ON CONFLICT (article_id, day) DO UPDATE SET clappers = EXCLUDED.clappers;
+
+INSERT INTO article_daily_stats AS s (article_id, day, comments)
+SELECT article_id, created_at::date, COUNT(*)
+FROM comments
+WHERE deleted_at IS NULL
+GROUP BY article_id, created_at::date
+ON CONFLICT (article_id, day) DO UPDATE SET comments = EXCLUDED.comments;
+
+-- +goose Down
+ALTER TABLE article_daily_stats
+    DROP COLUMN IF EXISTS comments,
+    DROP COLUMN IF EXISTS clappers;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- Each refresh writes a new generation of scores stamped with computed_at and
+-- the previous generation is kept until the next refresh, so a ranked list
+-- can page through the generation its first page came from.
+ALTER TABLE article_scores DROP CONSTRAINT article_scores_pkey;
+ALTER TABLE article_scores ADD PRIMARY KEY (article_id, time_window, computed_at);
+
+DROP INDEX IF EXISTS idx_article_scores_trending;
+DROP INDEX IF EXISTS idx_article_scores_top;
+CREATE INDEX idx_article_scores_trending ON article_scores(time_window, computed_at, trending DESC, article_id DESC);
+CREATE INDEX idx_article_scores_top ON article_scores(time_window, computed_at, top DESC, article_id DESC);
+CREATE INDEX idx_article_scores_computed_at ON article_scores(computed_at);
+
+-- +goose Down
+-- Keep only the latest generation so the old key holds
+DELETE FROM article_scores
+WHERE computed_at < (SELECT MAX(computed_at) FROM article_scores);
+
+DROP INDEX IF EXISTS idx_article_scores_computed_at;
+DROP INDEX IF EXISTS idx_article_scores_top;
+DROP INDEX IF EXISTS idx_article_scores_trending;
+CREATE INDEX idx_article_scores_trending ON article_scores(time_window, trending DESC, article_id DESC);
+CREATE INDEX idx_article_scores_top ON article_scores(time_window, top DESC, article_id DESC);
+
+ALTER TABLE article_scores DROP CONSTRAINT article_scores_pkey;
+ALTER TABLE article_scores ADD PRIMARY KEY (article_id, time_window);
@@ -83,6 +83,16 @@ export default function ArticlePage() {
       .finally(() => setIsLoading(false));
   }, [articleId, router]);
 
+  // Point search engines at the original when the article is a cross-post
+  useEffect(() => {
+    if (!article?.canonical_url) return;
+    const link = document.createElement("link");
+    link.rel = "canonical";
+    link.href = article.canonical_url;
+    document.head.appendChild(link);
+    return () => link.remove();
+  }, [article?.canonical_url]);
+
   async function handleClap() {
     if (!user) {
       toast.error("Sign in to clap");
@@ -189,6 +199,17 @@ export default function ArticlePage() {
         </div>
       )}
 
+      {article.canonical_url && (
+        <p className="mb-6 text-sm text-muted-foreground">
+          Originally published at{" "}
+          <a href={article.canonical_url} className="underline">
+            {new URL(article.canonical_url).host}
+          </a>
+          {article.original_published_at &&
+            ` on ${formatDate(article.original_published_at)}`}
+        </p>
+      )}
+
       <Separator className="mb-6" />
 
       {/* Article body */}
@@ -21,9 +21,11 @@ function getInitials(name: string) {
     .slice(0, 2);
 }
 
-function getReadingTime(body: string) {
-  const words = body.trim().split(/\s+/).length;
-  const minutes = Math.max(1, Math.ceil(words / 250));
+function getReadingTime(article: Article) {
+  // List endpoints omit the body and return a precomputed reading time
+  const minutes =
+    article.reading_time ??
+    Math.max(1, Math.ceil((article.body ?? "").trim().split(/\s+/).length / 250));
   return `${minutes} min read`;
 }
 
@@ -78,7 +80,7 @@ export function ArticleCard({ article }: { article: Article }) {
             <div className="flex flex-wrap items-center gap-2 text-xs text-muted-foreground">
               <span>{formatDate(displayDate)}</span>
               <span className="text-muted-foreground/50">·</span>
-              <span>{getReadingTime(article.body)}</span>
+              <span>{getReadingTime(article)}</span>
               {article.total_claps !== undefined && article.total_claps > 0 && (
                 <>
                   <span className="text-muted-foreground/50">·</span>
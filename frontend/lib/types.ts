@@ -6,6 +6,7 @@ export interface User {
   name: string;
   bio: string;
   avatar_url: string;
+  is_member?: boolean;
   created_at: string;
   updated_at: string;
 }
@@ -57,8 +58,13 @@ export interface Author {
   username: string;
   name: string;
   avatar_url: string;
+  co_authors?: Author[];
 }
 
+export type ArticleVisibility = "public" | "members";
+
+export type ArticleLanguage = "en" | "es" | "de" | "fr" | "pt" | "it" | "nl";
+
 export interface Article {
   id: string;
   user_id: string;
@@ -67,18 +73,48 @@ export interface Article {
   summary: string;
   thumbnail_url: string;
   status: "draft" | "published";
+  visibility?: ArticleVisibility;
+  locked?: boolean;
+  slug?: string;
+  reading_time?: number;
   published_at: string | null;
+  canonical_url?: string;
+  original_published_at?: string | null;
+  language?: ArticleLanguage;
+  translations?: ArticleTranslation[];
+  bookmarked?: boolean;
+  reading?: ReadingPosition;
+  finished?: boolean;
   created_at: string;
   updated_at: string;
   tags: string[];
   author?: Author;
+  publication?: PublicationRef | null;
   total_claps?: number;
   comment_count?: number;
+  clapper_count?: number;
+  score?: number;
+}
+
+export interface ArticleTranslation {
+  id: string;
+  title: string;
+  language: ArticleLanguage;
+  status: "draft" | "published";
+  slug: string;
+  published_at: string | null;
+}
+
+export interface PublicationRef {
+  id: string;
+  name: string;
+  slug: string;
 }
 
 export interface ArticleListResponse {
   articles: Article[];
   count: number;
+  next_cursor: string | null;
 }
 
 export interface CreateArticleRequest {
@@ -87,7 +123,11 @@ export interface CreateArticleRequest {
   summary?: string;
   thumbnail_url?: string;
   status?: "draft" | "published";
+  visibility?: ArticleVisibility;
   tags?: string[];
+  canonical_url?: string;
+  original_published_at?: string | null;
+  language?: ArticleLanguage;
 }
 
 export interface UpdateArticleRequest {
@@ -96,6 +136,166 @@ export interface UpdateArticleRequest {
   summary?: string;
   thumbnail_url?: string;
   tags?: string[];
+  canonical_url?: string;
+  original_published_at?: string | null;
+  language?: ArticleLanguage;
+}
+
+export interface ShareLink {
+  id: string;
+  label: string;
+  token: string;
+  created_by?: string;
+  expires_at: string | null;
+  revoked_at?: string | null;
+  created_at: string;
+}
+
+export interface ArticleNote {
+  id: string;
+  article_id: string;
+  body: string;
+  anchor: {
+    start: number;
+    end: number;
+    quote: string;
+    detached: boolean;
+  };
+  author: { username?: string; name: string };
+  resolved: boolean;
+  resolved_at: string | null;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface Highlight {
+  id: string;
+  article_id: string;
+  note: string;
+  anchor: {
+    start: number;
+    end: number;
+    quote: string;
+    detached: boolean;
+  };
+  created_at: string;
+  updated_at: string;
+}
+
+export interface TopHighlight {
+  start: number;
+  end: number;
+  quote: string;
+  count: number;
+}
+
+export interface ArticleHighlights {
+  top: TopHighlight[];
+  highlights: Highlight[];
+  count: number;
+}
+
+export interface ReadingList {
+  id: string;
+  name: string;
+  description: string;
+  is_public: boolean;
+  is_default: boolean;
+  item_count: number;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface ReadingListArticle extends Article {
+  position: number;
+  added_at: string;
+}
+
+export interface ReadingListDetail extends ReadingList {
+  owner: string;
+  articles: ReadingListArticle[];
+  count: number;
+  next_cursor: string | null;
+}
+
+export interface ReadingPosition {
+  progress: number;
+  finished: boolean;
+  finished_at: string | null;
+  last_read_at: string;
+}
+
+export interface HistoryArticle extends Article {
+  reading: ReadingPosition;
+  first_opened_at: string;
+}
+
+export interface ReadingHistory {
+  articles: HistoryArticle[];
+  count: number;
+  next_cursor: string | null;
+  paused: boolean;
+}
+
+export type FinishedMode = "show" | "last" | "hide";
+
+export type ImportSource = "medium" | "wordpress";
+
+export interface ImportReportItem {
+  key: string;
+  title: string;
+  action: "create" | "skip" | "fail";
+  reason?: string;
+  status?: "draft" | "published";
+  article_id?: string;
+}
+
+export interface ImportJob {
+  id: string;
+  source: ImportSource;
+  dry_run: boolean;
+  status: "pending" | "running" | "completed" | "failed";
+  error?: string;
+  report?: {
+    created: number;
+    skipped: number;
+    failed: number;
+    posts: ImportReportItem[];
+  };
+  created_at: string;
+  finished_at: string | null;
+}
+
+// ===== Stats =====
+export interface StatsCounts {
+  views: number;
+  reads: number;
+  read_ratio: number;
+  claps: number;
+}
+
+export interface DailyStats extends StatsCounts {
+  day: string;
+  followers_gained?: number;
+  followers_lost?: number;
+}
+
+export interface ArticleStats {
+  article_id: string;
+  since: string;
+  totals: StatsCounts;
+  daily: DailyStats[];
+}
+
+export interface UserStats {
+  since: string;
+  totals: StatsCounts & { followers_gained: number; followers_lost: number };
+  daily: DailyStats[];
+  articles: (StatsCounts & {
+    id: string;
+    title: string;
+    published_at: string | null;
+  })[];
 }
 
 // ===== Tags =====
@@ -109,16 +309,66 @@ export interface Tag {
 export interface Comment {
   id: string;
   article_id: string;
-  user_id: string;
+  user_id: string | null;
+  parent_id: string | null;
+  depth: number;
   body: string;
+  reply_count: number;
+  deleted: boolean;
   created_at: string;
   updated_at: string;
-  author?: Author;
+  edited_at: string | null;
+  author?: Author | null;
+  collapsed?: boolean;
 }
 
 export interface CommentListResponse {
   comments: Comment[];
   count: number;
+  next_cursor: string | null;
+}
+
+export interface CommentRepliesResponse {
+  replies: Comment[];
+  count: number;
+  next_cursor: string | null;
+}
+
+export interface CommentVersion {
+  id: string;
+  body: string;
+  replaced_at: string;
+}
+
+export interface CommentHistory {
+  comment: Comment;
+  versions: CommentVersion[];
+  count: number;
+}
+
+// ===== Mutes =====
+export interface MutedAuthor {
+  id: string;
+  username: string;
+  name: string;
+  avatar_url: string;
+  muted_at: string;
+}
+
+export interface MutedTag {
+  name: string;
+  muted_at: string;
+}
+
+export interface MutedKeyword {
+  keyword: string;
+  muted_at: string;
+}
+
+export interface Mutes {
+  authors: MutedAuthor[];
+  tags: MutedTag[];
+  keywords: MutedKeyword[];
 }
 
 // ===== Claps =====
@@ -132,10 +382,13 @@ export interface FollowListResponse {
   followers?: UserSummary[];
   following?: UserSummary[];
   count: number;
+  next_cursor: string | null;
 }
 
 // ===== Pagination =====
 export interface PaginationParams {
   limit?: number;
+  cursor?: string;
+  /** @deprecated use cursor */
   offset?: number;
 }